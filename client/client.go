@@ -4,11 +4,15 @@ package client
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/carrier-labs/go-bsn-cloud-client/debug"
@@ -18,21 +22,62 @@ const DefaultBaseAPI = "https://api.bsn.cloud/2022/06/REST"
 
 // Config holds configuration for the BSN.Cloud API client.
 type Config struct {
-	ClientID     string
-	ClientSecret string
-	BaseAPI      string        // Optional; if empty, DefaultBaseAPI is used
-	Timeout      time.Duration // Optional; if zero, 10s is used
-	NetworkName  string        // Optional; if set, network context is selected after auth
+	ClientID        string
+	ClientSecret    string          // Optional for public clients using GrantPassword
+	Username        string          // Optional; user name for GrantPassword
+	Password        string          // Optional; password for GrantPassword
+	GrantType       GrantType       // Optional; if empty, GrantPassword is used when Username is set, otherwise GrantClientCredentials
+	BaseAPI         string          // Optional; if empty, DefaultBaseAPI is used
+	AuthURL         string          // Optional; token endpoint; if empty, it is discovered from Issuer
+	Issuer          string          // Optional; OpenID Connect issuer used for discovery when AuthURL is empty; if both are empty, DefaultAuthURL is used
+	Timeout         time.Duration   // Optional; if zero, HTTPClient's timeout or 10s is used
+	HTTPClient      *http.Client    // Optional; base client for all calls, e.g. for proxies or mTLS; copied, not modified
+	Middlewares     []Middleware    // Optional; wrap the transport of every call, outermost first
+	Instrumentation Instrumentation // Optional; receives tracing spans and metrics; if nil, telemetry is disabled
+	NetworkName     string          // Optional; if set, network context is selected after auth
+	Retry           *RetryPolicy    // Optional; if nil, requests are sent once
+	RateLimit       *RateLimit      // Optional; if nil, requests are not rate limited
+	MaxConcurrency  int             // Optional; maximum concurrent DoRequest calls in flight; if zero, unlimited
+	TokenSource     TokenSource     // Optional; if nil, a source for GrantType is built from the credentials above
+	TokenCache      TokenCache      // Optional; if set, tokens are persisted and reused across processes
+	Logger          debug.Logger    // Optional; receives this client's logs; if nil, the package-level logger set with debug.SetLogger is used
+	RedactFields    []string        // Optional; JSON field names masked in logs in addition to DefaultRedactFields
 }
 
+// expirySkew is how long before expiry a token is treated as expired and refreshed.
+const expirySkew = 30 * time.Second
+
+// authTimeout bounds a shared authentication, which is detached from the callers' contexts,
+// so a stalled token or discovery request cannot block authentication indefinitely.
+const authTimeout = time.Minute
+
+// Client is a BSN.Cloud API client. A Client is safe for concurrent use by multiple
+// goroutines; concurrent callers that find the token missing or expired share a single
+// in-flight authentication request.
 type Client struct {
-	clientID     string
-	clientSecret string
-	baseAPI      string
-	httpClient   *http.Client
-	Token        string
-	Expiry       time.Time
-	NetworkName  string
+	clientID   string
+	credKey    string // identifies the credentials and auth server; see credentialKey
+	baseAPI    string
+	httpClient *http.Client
+	retry      *RetryPolicy
+	limiter    *limiter
+	instr      Instrumentation
+	tokens     TokenSource
+	cache      TokenCache
+	network    string
+	log        debug.LeveledLogger
+	redact     *redactor
+
+	mu     sync.Mutex // guards token and flight
+	token  *Token
+	flight *authFlight
+}
+
+// authFlight is an in-flight authentication shared by concurrent callers.
+type authFlight struct {
+	done  chan struct{}
+	token string
+	err   error
 }
 
 // New creates a new BSN.Cloud API client using the provided Config.
@@ -41,74 +86,295 @@ func New(cfg Config) *Client {
 	if baseAPI == "" {
 		baseAPI = DefaultBaseAPI
 	}
-	timeout := cfg.Timeout
-	if timeout == 0 {
-		timeout = 10 * time.Second
-	}
 	c := &Client{
+		clientID:   cfg.ClientID,
+		credKey:    credentialKey(cfg),
+		baseAPI:    baseAPI,
+		httpClient: newHTTPClient(cfg),
+		retry:      cfg.Retry,
+		limiter:    newLimiter(cfg.RateLimit, cfg.MaxConcurrency),
+		instr:      cfg.Instrumentation,
+		tokens:     cfg.TokenSource,
+		cache:      cfg.TokenCache,
+		network:    cfg.NetworkName,
+		log:        debug.Global(),
+		redact:     newRedactor(cfg.RedactFields),
+	}
+	if cfg.Logger != nil {
+		c.log = debug.Leveled(cfg.Logger)
+	}
+	if c.tokens == nil {
+		c.tokens = newTokenSource(cfg, c.httpClient, c.log, c.redact)
+	}
+	c.log.Debug("Client initialized", "clientID", cfg.ClientID, "baseAPI", baseAPI, "networkName", cfg.NetworkName)
+	return c
+}
+
+// WithNetwork returns a client for the named network. The returned client shares the
+// receiver's configuration, HTTP client, retry policy, rate limiter, token source,
+// token cache, logger and redaction settings, but holds its own token and BSN.Cloud
+// session, so clients for different networks can be used concurrently without switching
+// each other's session context.
+//
+// A custom TokenSource must issue a distinct token per call for sessions to stay
+// separate; the built-in grants do.
+func (c *Client) WithNetwork(name string) *Client {
+	return &Client{
+		clientID:   c.clientID,
+		credKey:    c.credKey,
+		baseAPI:    c.baseAPI,
+		httpClient: c.httpClient,
+		retry:      c.retry,
+		limiter:    c.limiter,
+		instr:      c.instr,
+		tokens:     c.tokens,
+		cache:      c.cache,
+		network:    name,
+		log:        c.log,
+		redact:     c.redact,
+	}
+}
+
+// Network returns the name of the network this client selects after authenticating,
+// or an empty string if none is configured.
+func (c *Client) Network() string {
+	return c.network
+}
+
+// Logger returns the logger the client writes to: Config.Logger, or the package-level
+// logger if none was configured. Services use it so their logs follow the client's.
+func (c *Client) Logger() debug.LeveledLogger {
+	return c.log
+}
+
+// newTokenSource builds the default TokenSource for the configured grant.
+func newTokenSource(cfg Config, httpClient *http.Client, log debug.LeveledLogger, redact *redactor) TokenSource {
+	endpoint := &tokenEndpoint{httpClient: httpClient, log: log, issuer: cfg.Issuer, url: cfg.AuthURL}
+	if endpoint.url == "" && endpoint.issuer == "" {
+		endpoint.url = DefaultAuthURL
+	}
+	oc := oauthClient{
+		httpClient:   httpClient,
+		endpoint:     endpoint,
 		clientID:     cfg.ClientID,
 		clientSecret: cfg.ClientSecret,
-		baseAPI:      baseAPI,
-		httpClient:   &http.Client{Timeout: timeout},
-		NetworkName:  cfg.NetworkName,
+		log:          log,
+		redact:       redact,
 	}
-	debug.Debug("Client initialized", "clientID", cfg.ClientID, "baseAPI", baseAPI, "networkName", cfg.NetworkName)
-	return c
+	if grantType(cfg) == GrantPassword {
+		return &passwordSource{oauthClient: oc, username: cfg.Username, password: cfg.Password}
+	}
+	return &clientCredentialsSource{oauthClient: oc}
 }
 
-// Authenticate fetches and caches an access token for the BSN.Cloud API.
+// grantType returns the grant the default TokenSource uses for cfg.
+func grantType(cfg Config) GrantType {
+	switch {
+	case cfg.GrantType != "":
+		return cfg.GrantType
+	case cfg.Username != "":
+		return GrantPassword
+	}
+	return GrantClientCredentials
+}
+
+// credentialKey identifies the credentials and auth server tokens are issued for, so
+// users sharing a public ClientID and a TokenCache never load each other's tokens.
+func credentialKey(cfg Config) string {
+	authURL := cfg.AuthURL
+	if authURL == "" && cfg.Issuer == "" {
+		authURL = DefaultAuthURL
+	}
+	return strings.Join([]string{cfg.ClientID, string(grantType(cfg)), cfg.Username, authURL, cfg.Issuer}, "\x00")
+}
+
+// Authenticate ensures the client holds a valid access token for the BSN.Cloud API,
+// fetching one if the cached token is missing or about to expire.
 func (c *Client) Authenticate(ctx context.Context) error {
-	if time.Now().Before(c.Expiry.Add(-30 * time.Second)) {
-		return nil
+	_, err := c.accessToken(ctx)
+	return err
+}
+
+// Token returns the cached access token and its expiry. The token is empty if the
+// client has not authenticated yet.
+func (c *Client) Token() (string, time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token == nil {
+		return "", time.Time{}
 	}
+	return c.token.AccessToken, c.token.Expiry
+}
 
-	type bsnAuthResp struct {
-		AccessToken string `json:"access_token"`
-		ExpiresIn   int    `json:"expires_in"`
-		TokenType   string `json:"token_type"`
+// accessToken returns a valid access token, joining or starting a shared refresh if needed.
+// The refresh itself is not bound to ctx, so one caller giving up does not fail the others;
+// it is limited to authTimeout instead.
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	if c.token.Valid() {
+		token := c.token.AccessToken
+		c.mu.Unlock()
+		return token, nil
+	}
+	f := c.flight
+	if f == nil {
+		f = &authFlight{done: make(chan struct{})}
+		c.flight = f
+		go c.refresh(context.WithoutCancel(ctx), f)
 	}
+	c.mu.Unlock()
 
-	url := "https://auth.bsn.cloud/realms/bsncloud/protocol/openid-connect/token"
-	form := []byte("grant_type=client_credentials")
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(form))
-	if err != nil {
-		return err
+	select {
+	case <-f.done:
+		return f.token, f.err
+	case <-ctx.Done():
+		return "", ctx.Err()
 	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	auth := base64.StdEncoding.EncodeToString([]byte(c.clientID + ":" + c.clientSecret))
-	req.Header.Set("Authorization", "Basic "+auth)
+}
 
-	resp, err := c.httpClient.Do(req)
+// refresh obtains a token, selects the configured network and publishes the result to f.
+func (c *Client) refresh(ctx context.Context, f *authFlight) {
+	ctx, cancel := context.WithTimeout(ctx, authTimeout)
+	defer cancel()
+	ctx, end := c.startOperation(ctx, Operation{Name: OperationAuthenticate})
+	token, err := c.obtainToken(ctx)
+	end(&Outcome{Err: err})
 	if err != nil {
-		return err
+		c.log.Error("Client: authentication failed", "network", c.network, "error", err)
 	}
-	debug.Debug("API call", "method", req.Method, "url", req.URL.String())
-	defer resp.Body.Close()
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		debug.Debug("API error response", "status", resp.Status, "body", string(body))
-		return fmt.Errorf("auth failed: %s -- %s", resp.Status, body)
+
+	c.mu.Lock()
+	if err == nil {
+		c.token = token
 	}
+	c.flight = nil
+	c.mu.Unlock()
 
-	var ar bsnAuthResp
-	if err := json.NewDecoder(resp.Body).Decode(&ar); err != nil {
-		return err
+	if err == nil {
+		f.token = token.AccessToken
+	}
+	f.err = err
+	close(f.done)
+}
+
+// obtainToken returns a token with the configured network selected. It prefers, in order,
+// a valid token from the persistent cache, a refresh of the current or cached token, and
+// finally a new token from the TokenSource.
+func (c *Client) obtainToken(ctx context.Context) (*Token, error) {
+	c.mu.Lock()
+	current := c.token
+	c.mu.Unlock()
+	if current == nil {
+		current = c.loadCachedToken(ctx)
 	}
-	c.Token = ar.AccessToken
-	c.Expiry = time.Now().Add(time.Duration(ar.ExpiresIn) * time.Second)
 
-	// Set network context if configured
-	if c.NetworkName != "" {
-		if err := c.SelectNetwork(ctx); err != nil {
-			return fmt.Errorf("network selection error: %w", err)
+	if current.Valid() {
+		err := c.selectNetwork(ctx, current.AccessToken)
+		if err == nil {
+			return current, nil
 		}
+		if !IsUnauthorized(err) {
+			return nil, fmt.Errorf("network selection error: %w", err)
+		}
+		c.log.Warn("Client: cached token rejected", "error", err)
+		c.deleteCachedToken(ctx)
+		current = nil
+	}
+
+	token := c.refreshToken(ctx, current)
+	if token == nil {
+		var err error
+		if token, err = c.tokens.Token(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if !token.Valid() {
+		return nil, errors.New("token source returned an invalid token")
+	}
+	if err := c.selectNetwork(ctx, token.AccessToken); err != nil {
+		return nil, fmt.Errorf("network selection error: %w", err)
+	}
+	if c.cache != nil {
+		if err := c.cache.Save(ctx, c.cacheKey(), token); err != nil {
+			c.log.Warn("Client: token cache save error", "error", err)
+		}
+	}
+	return token, nil
+}
+
+// refreshToken renews t with its refresh token if the TokenSource supports it. It returns
+// nil if no refresh is possible or the refresh fails, so the caller falls back to a full login.
+func (c *Client) refreshToken(ctx context.Context, t *Token) *Token {
+	refresher, ok := c.tokens.(TokenRefresher)
+	if !ok || !t.CanRefresh() {
+		return nil
+	}
+	token, err := refresher.Refresh(ctx, t)
+	if err != nil {
+		c.log.Warn("Client: token refresh failed, logging in again", "error", err)
+		return nil
+	}
+	return token
+}
+
+// invalidateToken discards the cached token if it is still stale, so the next caller refreshes it.
+func (c *Client) invalidateToken(ctx context.Context, stale string) {
+	c.mu.Lock()
+	current := c.token != nil && c.token.AccessToken == stale
+	if current {
+		c.token = nil
+	}
+	c.mu.Unlock()
+	if current {
+		c.deleteCachedToken(ctx)
+	}
+}
+
+// cacheKey identifies this client's credentials, API and network in the TokenCache.
+// The parts are hashed so caches never see user names or client IDs.
+func (c *Client) cacheKey() string {
+	sum := sha256.Sum256([]byte(c.credKey + "\x00" + c.baseAPI + "\x00" + c.network))
+	return hex.EncodeToString(sum[:])
+}
+
+// loadCachedToken returns the token in the persistent cache, or nil. The token may be
+// expired but still carry a usable refresh token.
+func (c *Client) loadCachedToken(ctx context.Context) *Token {
+	if c.cache == nil {
+		return nil
+	}
+	token, err := c.cache.Load(ctx, c.cacheKey())
+	if err != nil {
+		c.log.Warn("Client: token cache load error", "error", err)
+		return nil
+	}
+	return token
+}
+
+// deleteCachedToken removes this client's entry from the persistent cache.
+func (c *Client) deleteCachedToken(ctx context.Context) {
+	if c.cache == nil {
+		return
+	}
+	if err := c.cache.Delete(ctx, c.cacheKey()); err != nil {
+		c.log.Warn("Client: token cache delete error", "error", err)
 	}
-	return nil
 }
 
 // DoRequest performs an HTTP request with context and returns the response body.
-func (c *Client) DoRequest(ctx context.Context, method, endpoint string, body interface{}) ([]byte, error) {
-	var reqBody io.Reader
+// The client authenticates on first use, and if the API rejects the token with
+// 401 Unauthorized the token is refreshed, the network re-selected and the request
+// replayed once. A 204 No Content response returns a nil body and no error. Responses
+// with a 4xx or 5xx status return an *APIError. Failed requests are retried according
+// to Config.Retry.
+func (c *Client) DoRequest(ctx context.Context, method, endpoint string, body interface{}) (respBody []byte, err error) {
+	ctx, end := c.startOperation(ctx, Operation{Name: OperationRequest, Method: method, Endpoint: endpointTemplate(ctx, endpoint)})
+	out := &Outcome{}
+	defer func() {
+		out.Err = err
+		end(out)
+	}()
+
 	var bodyBytes []byte
 	if body != nil {
 		b, err := json.Marshal(body)
@@ -116,50 +382,99 @@ func (c *Client) DoRequest(ctx context.Context, method, endpoint string, body in
 			return nil, err
 		}
 		bodyBytes = b
-		reqBody = bytes.NewBuffer(b)
+	}
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("authentication error: %w", err)
+	}
+	respBody, err = c.doRequestWithRetry(ctx, token, method, endpoint, bodyBytes, out)
+	if !IsUnauthorized(err) {
+		return respBody, err
+	}
+
+	c.log.Info("DoRequest: token rejected, re-authenticating", "method", method, "endpoint", endpoint)
+	c.invalidateToken(ctx, token)
+	if token, err = c.accessToken(ctx); err != nil {
+		return nil, fmt.Errorf("authentication error: %w", err)
+	}
+	return c.doRequestWithRetry(ctx, token, method, endpoint, bodyBytes, out)
+}
+
+// doRequestWithRetry sends a request, retrying according to the client's RetryPolicy.
+// Attempts and the last status received are recorded in out.
+func (c *Client) doRequestWithRetry(ctx context.Context, token, method, endpoint string, bodyBytes []byte, out *Outcome) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		out.Attempts++
+		respBody, status, err := c.doRequestOnce(ctx, token, method, endpoint, bodyBytes)
+		out.StatusCode = status
+		if err == nil || !c.retry.shouldRetry(method, attempt, err) {
+			return respBody, err
+		}
+		delay, ok := c.retry.backoff(attempt, err)
+		if !ok {
+			c.log.Warn("DoRequest: not retrying, server delay exceeds MaxBackoff", "method", method, "endpoint", endpoint, "attempt", attempt, "delay", delay)
+			return respBody, err
+		}
+		c.log.Warn("DoRequest: retrying", "method", method, "endpoint", endpoint, "attempt", attempt, "delay", delay, "error", err)
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// doRequestOnce sends a single attempt of a DoRequest call and returns the body and HTTP
+// status. The status is 0 if no response was received.
+func (c *Client) doRequestOnce(ctx context.Context, token, method, endpoint string, bodyBytes []byte) ([]byte, int, error) {
+	release, err := c.limiter.acquire(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer release()
+
+	var reqBody io.Reader
+	if bodyBytes != nil {
+		reqBody = bytes.NewReader(bodyBytes)
 	}
 	url := c.baseAPI + endpoint
 	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	if c.Token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.Token)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	// Log request details
-	reqHeaders := map[string][]string{}
-	for k, v := range req.Header {
-		reqHeaders[k] = v
-	}
-	debug.Debug("DoRequest: request", "method", req.Method, "url", req.URL.String(), "headers", reqHeaders, "body", string(bodyBytes))
+	// Log request details, with credentials and secret fields masked
+	c.log.Debug("DoRequest: request", "method", req.Method, "url", req.URL.String(), "headers", c.redact.headers(req.Header), "body", c.redact.body(bodyBytes))
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer resp.Body.Close()
 
 	// Log response headers
-	respHeaders := map[string][]string{}
-	for k, v := range resp.Header {
-		respHeaders[k] = v
-	}
-	debug.Debug("DoRequest: response status", "status", resp.StatusCode, "headers", respHeaders)
+	c.log.Debug("DoRequest: response status", "status", resp.StatusCode, "headers", c.redact.headers(resp.Header))
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
-	}
-	if len(respBody) == 0 {
-		return nil, fmt.Errorf("API returned empty response body (status %d)", resp.StatusCode)
+		return nil, resp.StatusCode, err
 	}
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error: %s", respBody)
+		return nil, resp.StatusCode, newAPIError(resp, method, endpoint, respBody, c.redact)
+	}
+	if len(respBody) == 0 && resp.StatusCode != http.StatusNoContent {
+		return nil, resp.StatusCode, fmt.Errorf("API returned empty response body (status %d)", resp.StatusCode)
 	}
-	return respBody, nil
+	return respBody, resp.StatusCode, nil
+}
+
+// LimiterStats reports time spent waiting on Config.RateLimit and Config.MaxConcurrency.
+// Clients derived with WithNetwork share, and report, the same limiter.
+func (c *Client) LimiterStats() LimiterStats {
+	return c.limiter.stats()
 }
 
 // HttpClient returns the underlying http.Client for advanced use.
@@ -167,20 +482,39 @@ func (c *Client) HttpClient() *http.Client {
 	return c.httpClient
 }
 
-// SelectNetwork sets the active network context for the client.
+// SelectNetwork sets the active network context for the client, authenticating first if needed.
 func (c *Client) SelectNetwork(ctx context.Context) error {
-	if c.NetworkName == "" {
+	if c.network == "" {
+		return nil
+	}
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+	return c.selectNetwork(ctx, token)
+}
+
+// selectNetwork sets the session network for token. It does nothing if no network is configured.
+func (c *Client) selectNetwork(ctx context.Context, token string) (err error) {
+	if c.network == "" {
 		return nil
 	}
+	ctx, end := c.startOperation(ctx, Operation{Name: OperationSelectNetwork, Method: "PUT", Endpoint: "/self/session/network"})
+	out := &Outcome{Attempts: 1}
+	defer func() {
+		out.Err = err
+		end(out)
+	}()
+
 	url := c.baseAPI + "/self/session/network"
-	body := map[string]string{"name": c.NetworkName}
+	body := map[string]string{"name": c.network}
 	buf, _ := json.Marshal(body)
 
 	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(buf))
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
 
@@ -188,15 +522,16 @@ func (c *Client) SelectNetwork(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	debug.Debug("API call", "method", req.Method, "url", req.URL.String(), "body", string(buf), "response_status", resp.StatusCode)
-	for k, v := range resp.Header {
-		debug.Debug("API response header", "key", k, "value", v)
+	out.StatusCode = resp.StatusCode
+	c.log.Debug("API call", "method", req.Method, "url", req.URL.String(), "body", c.redact.body(buf), "response_status", resp.StatusCode)
+	for k, v := range c.redact.headers(resp.Header) {
+		c.log.Debug("API response header", "key", k, "value", v)
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != 204 {
 		body, _ := io.ReadAll(resp.Body)
-		debug.Debug("API error response", "status", resp.Status, "body", string(body))
-		return fmt.Errorf("network select failed: %s", resp.Status)
+		c.log.Debug("API error response", "status", resp.Status, "body", c.redact.body(body))
+		return fmt.Errorf("network select failed: %w", newAPIError(resp, req.Method, "/self/session/network", body, c.redact))
 	}
 	return nil
 }
@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testServer is a fake auth server and BSN.Cloud API. Token requests to /token return
+// "tok-1", "tok-2", ... in turn; all other requests are passed to api.
+type testServer struct {
+	*httptest.Server
+	tokenCalls atomic.Int32
+	apiCalls   atomic.Int32
+}
+
+func newTestServer(t *testing.T, tokenDelay time.Duration, api http.HandlerFunc) *testServer {
+	t.Helper()
+	ts := &testServer{}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			n := ts.tokenCalls.Add(1)
+			time.Sleep(tokenDelay)
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"access_token":"tok-%d","expires_in":3600,"token_type":"Bearer"}`, n)
+			return
+		}
+		ts.apiCalls.Add(1)
+		api(w, r)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// config returns a Config for a confidential client of ts.
+func (ts *testServer) config() Config {
+	return Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		AuthURL:      ts.URL + "/token",
+		BaseAPI:      ts.URL,
+	}
+}
+
+// okHandler answers every API request with an empty JSON object.
+func okHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Write([]byte(`{}`))
+}
+
+func TestAccessTokenSingleFlight(t *testing.T) {
+	ts := newTestServer(t, 50*time.Millisecond, okHandler)
+	c := New(ts.config())
+
+	const callers = 20
+	var wg sync.WaitGroup
+	tokens := make([]string, callers)
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tokens[i], errs[i] = c.accessToken(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	if n := ts.tokenCalls.Load(); n != 1 {
+		t.Errorf("token requests = %d, want 1", n)
+	}
+	for i := range callers {
+		if errs[i] != nil || tokens[i] != "tok-1" {
+			t.Errorf("caller %d got %q, %v; want tok-1", i, tokens[i], errs[i])
+		}
+	}
+}
+
+func TestAccessTokenCallerCancelDoesNotFailFlight(t *testing.T) {
+	ts := newTestServer(t, 100*time.Millisecond, okHandler)
+	c := New(ts.config())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.accessToken(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("accessToken() error = %v, want context.DeadlineExceeded", err)
+	}
+	token, err := c.accessToken(context.Background())
+	if err != nil || token != "tok-1" {
+		t.Fatalf("accessToken() = %q, %v; want tok-1", token, err)
+	}
+	if n := ts.tokenCalls.Load(); n != 1 {
+		t.Errorf("token requests = %d, want 1", n)
+	}
+}
+
+func TestInvalidateToken(t *testing.T) {
+	ts := newTestServer(t, 0, okHandler)
+	c := New(ts.config())
+	ctx := context.Background()
+
+	if err := c.Authenticate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// A stale token that is no longer current must not discard the current one.
+	c.invalidateToken(ctx, "tok-0")
+	if token, _ := c.Token(); token != "tok-1" {
+		t.Fatalf("Token() after stale invalidate = %q, want tok-1", token)
+	}
+
+	c.invalidateToken(ctx, "tok-1")
+	if token, _ := c.Token(); token != "" {
+		t.Fatalf("Token() after invalidate = %q, want empty", token)
+	}
+	token, err := c.accessToken(ctx)
+	if err != nil || token != "tok-2" {
+		t.Fatalf("accessToken() = %q, %v; want tok-2", token, err)
+	}
+}
+
+func TestConcurrentInvalidateRefreshesOnce(t *testing.T) {
+	ts := newTestServer(t, 20*time.Millisecond, okHandler)
+	c := New(ts.config())
+	ctx := context.Background()
+	if err := c.Authenticate(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	const callers = 10
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.invalidateToken(ctx, "tok-1")
+			if _, err := c.accessToken(ctx); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := ts.tokenCalls.Load(); n != 2 {
+		t.Errorf("token requests = %d, want 2", n)
+	}
+}
+
+func TestDoRequestReplaysOnceAfter401(t *testing.T) {
+	ts := newTestServer(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	})
+	c := New(ts.config())
+
+	body, err := c.DoRequest(context.Background(), "GET", "/self", nil)
+	if err != nil {
+		t.Fatalf("DoRequest() error: %v", err)
+	}
+	if string(body) != `{"ok":true}` {
+		t.Errorf("DoRequest() body = %s", body)
+	}
+	if n := ts.tokenCalls.Load(); n != 2 {
+		t.Errorf("token requests = %d, want 2", n)
+	}
+	if n := ts.apiCalls.Load(); n != 2 {
+		t.Errorf("API requests = %d, want 2", n)
+	}
+}
+
+func TestDoRequestGivesUpAfterSecond401(t *testing.T) {
+	ts := newTestServer(t, 0, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	c := New(ts.config())
+
+	_, err := c.DoRequest(context.Background(), "GET", "/self", nil)
+	if !IsUnauthorized(err) {
+		t.Fatalf("DoRequest() error = %v, want 401", err)
+	}
+	if n := ts.tokenCalls.Load(); n != 2 {
+		t.Errorf("token requests = %d, want 2", n)
+	}
+	if n := ts.apiCalls.Load(); n != 2 {
+		t.Errorf("API requests = %d, want 2 (one replay)", n)
+	}
+}
@@ -0,0 +1,154 @@
+// Package client provides the core HTTP client, authentication, and configuration for BSN.Cloud API.
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrNotFound matches any *APIError with status 404 Not Found when used with errors.Is.
+var ErrNotFound = errors.New("not found")
+
+// APIError is returned for any BSN.Cloud or auth server response with a 4xx or 5xx status.
+type APIError struct {
+	StatusCode int           // HTTP status code
+	Status     string        // HTTP status line, e.g. "404 Not Found"
+	Method     string        // Request method
+	Endpoint   string        // Request endpoint, relative to the base API for REST calls
+	Code       string        // Error code from the response body, if any
+	Message    string        // Error message from the response body, if any
+	Body       []byte        // Raw response body; Error shows a redacted copy
+	RetryAfter time.Duration // Delay requested by a Retry-After header on 429 and 503 responses
+
+	redactedBody string // Body with secret fields masked, for Error
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "API error: %s %s: %s", e.Method, e.Endpoint, e.Status)
+	switch {
+	case e.Code != "" && e.Message != "":
+		fmt.Fprintf(&b, ": %s: %s", e.Code, e.Message)
+	case e.Message != "":
+		fmt.Fprintf(&b, ": %s", e.Message)
+	case e.Code != "":
+		fmt.Fprintf(&b, ": %s", e.Code)
+	case e.redactedBody != "":
+		fmt.Fprintf(&b, ": %s", e.redactedBody)
+	}
+	return b.String()
+}
+
+// Is reports whether target is ErrNotFound and the error is a 404.
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// newAPIError builds an APIError from a failed response, parsing any error fields in body.
+// The copy of body shown by Error is masked with redact, or DefaultRedactFields if nil.
+func newAPIError(resp *http.Response, method, endpoint string, body []byte, redact *redactor) *APIError {
+	if redact == nil {
+		redact = newRedactor(nil)
+	}
+	e := &APIError{
+		StatusCode:   resp.StatusCode,
+		Status:       resp.Status,
+		Method:       method,
+		Endpoint:     endpoint,
+		Body:         body,
+		redactedBody: redact.body(body),
+	}
+	e.Code, e.Message = parseErrorBody(body)
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		e.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+	}
+	return e
+}
+
+// parseErrorBody extracts an error code and message from the JSON error shapes used by
+// the REST API ({"error": {"code", "message"}} or {"code", "message"}) and the OpenID
+// Connect auth server ({"error", "error_description"}).
+func parseErrorBody(body []byte) (code, message string) {
+	var fields struct {
+		Error            json.RawMessage `json:"error"`
+		ErrorDescription string          `json:"error_description"`
+		Code             json.RawMessage `json:"code"`
+		Message          string          `json:"message"`
+		Title            string          `json:"title"`
+		Detail           string          `json:"detail"`
+	}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return "", ""
+	}
+	code = rawString(fields.Code)
+	message = fields.Message
+	if len(fields.Error) > 0 {
+		var nested struct {
+			Code    json.RawMessage `json:"code"`
+			Message string          `json:"message"`
+		}
+		if err := json.Unmarshal(fields.Error, &nested); err == nil {
+			code, message = rawString(nested.Code), nested.Message
+		} else {
+			code, message = rawString(fields.Error), fields.ErrorDescription
+		}
+	}
+	if message == "" {
+		message = fields.Detail
+	}
+	if message == "" {
+		message = fields.Title
+	}
+	return code, message
+}
+
+// rawString returns a JSON string or number as plain text.
+func rawString(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// StatusCode returns the HTTP status of err if it is or wraps an *APIError, or 0 otherwise.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is or wraps an *APIError with status 404.
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether err is or wraps an *APIError with status 401.
+func IsUnauthorized(err error) bool {
+	return StatusCode(err) == http.StatusUnauthorized
+}
+
+// IsForbidden reports whether err is or wraps an *APIError with status 403.
+func IsForbidden(err error) bool {
+	return StatusCode(err) == http.StatusForbidden
+}
+
+// IsRateLimited reports whether err is or wraps an *APIError with status 429.
+func IsRateLimited(err error) bool {
+	return StatusCode(err) == http.StatusTooManyRequests
+}
+
+// IsServerError reports whether err is or wraps an *APIError with a 5xx status.
+func IsServerError(err error) bool {
+	return StatusCode(err) >= 500
+}
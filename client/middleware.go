@@ -0,0 +1,51 @@
+// Package client provides the core HTTP client, authentication, and configuration for BSN.Cloud API.
+package client
+
+import (
+	"net/http"
+	"time"
+)
+
+// Middleware wraps an http.RoundTripper to observe or modify requests and responses.
+// Middlewares apply to every HTTP call the client makes, including token requests,
+// OpenID Connect discovery, network selection and DoRequest.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts an ordinary function to an http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// chain wraps base with mws so that mws[0] is the outermost and sees requests first.
+func chain(base http.RoundTripper, mws []Middleware) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	for i := len(mws) - 1; i >= 0; i-- {
+		if mws[i] != nil {
+			base = mws[i](base)
+		}
+	}
+	return base
+}
+
+// newHTTPClient builds the client's http.Client from cfg. A caller-supplied
+// Config.HTTPClient is copied rather than modified.
+func newHTTPClient(cfg Config) *http.Client {
+	var hc http.Client
+	if cfg.HTTPClient != nil {
+		hc = *cfg.HTTPClient
+	} else {
+		hc.Timeout = 10 * time.Second
+	}
+	if cfg.Timeout != 0 {
+		hc.Timeout = cfg.Timeout
+	}
+	if len(cfg.Middlewares) > 0 {
+		hc.Transport = chain(hc.Transport, cfg.Middlewares)
+	}
+	return &hc
+}
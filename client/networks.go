@@ -0,0 +1,50 @@
+// Package client provides the core HTTP client, authentication, and configuration for BSN.Cloud API.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/carrier-labs/go-bsn-cloud-client/models"
+)
+
+// networksPageSize is the number of networks requested per page by Networks.
+const networksPageSize = 100
+
+// Networks lists every network the client's credentials can access, walking all pages.
+// Combine it with WithNetwork to work across networks:
+//
+//	networks, err := c.Networks(ctx)
+//	for _, n := range networks {
+//		devices := service.NewDeviceService(c.WithNetwork(n.Name))
+//		...
+//	}
+func (c *Client) Networks(ctx context.Context) ([]models.Network, error) {
+	var networks []models.Network
+	marker := ""
+	for page := 1; ; page++ {
+		params := url.Values{}
+		params.Set("pageSize", strconv.Itoa(networksPageSize))
+		if marker != "" {
+			params.Set("marker", marker)
+		}
+		respBody, err := c.DoRequest(ctx, "GET", "/self/networks?"+params.Encode(), nil)
+		if err != nil {
+			return networks, fmt.Errorf("fetching networks page %d: %w", page, err)
+		}
+
+		var result models.NetworkListResponse
+		if err := json.Unmarshal(respBody, &result); err != nil {
+			c.log.Error("Client: networks decode error", "error", err)
+			return networks, fmt.Errorf("parsing networks: %w", err)
+		}
+		networks = append(networks, result.Items...)
+		if !result.IsTruncated || result.NextMarker == "" {
+			return networks, nil
+		}
+		marker = result.NextMarker
+	}
+}
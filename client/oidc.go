@@ -0,0 +1,96 @@
+// Package client provides the core HTTP client, authentication, and configuration for BSN.Cloud API.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/carrier-labs/go-bsn-cloud-client/debug"
+)
+
+// DefaultIssuer is the OpenID Connect issuer of the BSN.Cloud auth server.
+const DefaultIssuer = "https://auth.bsn.cloud/realms/bsncloud"
+
+// DefaultAuthURL is the token endpoint of the BSN.Cloud auth server.
+const DefaultAuthURL = DefaultIssuer + "/protocol/openid-connect/token"
+
+// ProviderMetadata is the subset of OpenID Connect discovery metadata used by the client.
+type ProviderMetadata struct {
+	Issuer        string `json:"issuer"`         // Issuer identifier
+	TokenEndpoint string `json:"token_endpoint"` // OAuth2 token endpoint
+}
+
+// Discover fetches the OpenID Connect discovery document for issuer from
+// {issuer}/.well-known/openid-configuration.
+func Discover(ctx context.Context, httpClient *http.Client, issuer string) (*ProviderMetadata, error) {
+	return discover(ctx, httpClient, issuer, debug.Global())
+}
+
+// discover implements Discover, logging to log.
+func discover(ctx context.Context, httpClient *http.Client, issuer string, log debug.LeveledLogger) (*ProviderMetadata, error) {
+	issuer = strings.TrimSuffix(issuer, "/")
+	url := issuer + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("API call", "method", req.Method, "url", req.URL.String(), "response_status", resp.StatusCode)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("OIDC discovery failed: %w", newAPIError(resp, req.Method, url, body, nil))
+	}
+
+	var md ProviderMetadata
+	if err := json.Unmarshal(body, &md); err != nil {
+		return nil, fmt.Errorf("parsing OIDC discovery document: %w", err)
+	}
+	if strings.TrimSuffix(md.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("OIDC discovery: issuer %q does not match %q", md.Issuer, issuer)
+	}
+	if md.TokenEndpoint == "" {
+		return nil, fmt.Errorf("OIDC discovery: no token_endpoint for issuer %q", issuer)
+	}
+	return &md, nil
+}
+
+// tokenEndpoint resolves the token URL, either fixed or discovered from an issuer on first use.
+type tokenEndpoint struct {
+	httpClient *http.Client
+	log        debug.LeveledLogger
+	issuer     string
+
+	mu  sync.Mutex // guards url
+	url string
+}
+
+// resolve returns the token URL, running discovery if it is not known yet. Failed
+// discoveries are not cached, so the next call tries again.
+func (e *tokenEndpoint) resolve(ctx context.Context) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.url != "" {
+		return e.url, nil
+	}
+	md, err := discover(ctx, e.httpClient, e.issuer, e.log)
+	if err != nil {
+		return "", err
+	}
+	e.url = md.TokenEndpoint
+	e.log.Info("Client: discovered token endpoint", "issuer", e.issuer, "tokenEndpoint", e.url)
+	return e.url, nil
+}
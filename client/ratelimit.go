@@ -0,0 +1,131 @@
+// Package client provides the core HTTP client, authentication, and configuration for BSN.Cloud API.
+package client
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimit configures a client-side token bucket applied to every DoRequest attempt.
+type RateLimit struct {
+	RequestsPerSecond float64 // Sustained request rate; zero or less disables the limiter
+	Burst             int     // Optional; maximum requests sent back to back; if zero, 1 is used
+}
+
+// LimiterStats reports how much the rate limiter and concurrency cap have throttled a client.
+type LimiterStats struct {
+	Requests int64         // Requests admitted
+	Waited   int64         // Requests that had to wait before being sent
+	WaitTime time.Duration // Total time spent waiting
+	InFlight int64         // Requests currently being sent
+}
+
+// limiter combines a token bucket and a concurrency semaphore. It is shared by a Client
+// and all clients derived from it with WithNetwork.
+type limiter struct {
+	rate  float64
+	burst float64
+	sem   chan struct{}
+
+	mu     sync.Mutex // guards tokens and last
+	tokens float64
+	last   time.Time
+
+	requests atomic.Int64
+	waited   atomic.Int64
+	waitTime atomic.Int64
+	inFlight atomic.Int64
+}
+
+// newLimiter returns a limiter for rl and maxConcurrency, or nil if neither is set.
+func newLimiter(rl *RateLimit, maxConcurrency int) *limiter {
+	l := &limiter{}
+	if rl != nil && rl.RequestsPerSecond > 0 {
+		l.rate = rl.RequestsPerSecond
+		l.burst = float64(max(rl.Burst, 1))
+		l.tokens = l.burst
+		l.last = time.Now()
+	}
+	if maxConcurrency > 0 {
+		l.sem = make(chan struct{}, maxConcurrency)
+	}
+	if l.rate == 0 && l.sem == nil {
+		return nil
+	}
+	return l
+}
+
+// acquire waits for a rate token and a concurrency slot. The returned release function
+// must be called when the request completes. A nil limiter admits immediately.
+func (l *limiter) acquire(ctx context.Context) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	start := time.Now()
+	if err := l.waitToken(ctx); err != nil {
+		return nil, err
+	}
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if waited := time.Since(start); waited > time.Millisecond {
+		l.waited.Add(1)
+		l.waitTime.Add(int64(waited))
+	}
+	l.requests.Add(1)
+	l.inFlight.Add(1)
+	return func() {
+		l.inFlight.Add(-1)
+		if l.sem != nil {
+			<-l.sem
+		}
+	}, nil
+}
+
+// waitToken reserves one token from the bucket and sleeps until it is available.
+// If ctx is cancelled while waiting, the reservation is returned.
+func (l *limiter) waitToken(ctx context.Context) error {
+	if l.rate == 0 {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+	if err := sleep(ctx, wait); err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// stats returns a snapshot of the limiter counters.
+func (l *limiter) stats() LimiterStats {
+	if l == nil {
+		return LimiterStats{}
+	}
+	return LimiterStats{
+		Requests: l.requests.Load(),
+		Waited:   l.waited.Load(),
+		WaitTime: time.Duration(l.waitTime.Load()),
+		InFlight: l.inFlight.Load(),
+	}
+}
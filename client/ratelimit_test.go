@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	ts := newTestServer(t, 0, func(w http.ResponseWriter, _ *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{}`))
+	})
+	cfg := ts.config()
+	cfg.MaxConcurrency = 2
+	c := New(cfg)
+
+	const requests = 10
+	var wg sync.WaitGroup
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.DoRequest(context.Background(), "GET", "/self", nil); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if p := peak.Load(); p > 2 {
+		t.Errorf("peak concurrent requests = %d, want at most 2", p)
+	}
+	stats := c.LimiterStats()
+	if stats.Requests != requests || stats.InFlight != 0 {
+		t.Errorf("LimiterStats() = %+v, want %d requests and none in flight", stats, requests)
+	}
+	if stats.Waited == 0 || stats.WaitTime == 0 {
+		t.Errorf("LimiterStats() = %+v, want waits recorded", stats)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	l := newLimiter(&RateLimit{RequestsPerSecond: 50, Burst: 2}, 0)
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 6 {
+		release, err := l.acquire(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+	// Two requests fit the burst; the other four wait 20ms each.
+	if elapsed := time.Since(start); elapsed < 70*time.Millisecond {
+		t.Errorf("6 requests took %v, want about 80ms", elapsed)
+	}
+	if stats := l.stats(); stats.Requests != 6 || stats.Waited < 3 {
+		t.Errorf("stats() = %+v, want 6 requests and at least 3 waits", stats)
+	}
+}
+
+func TestRateLimitCancel(t *testing.T) {
+	l := newLimiter(&RateLimit{RequestsPerSecond: 1}, 0)
+	release, err := l.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("acquire() error = %v, want context.DeadlineExceeded", err)
+	}
+	// The cancelled reservation is returned, so the bucket is not overdrawn.
+	l.mu.Lock()
+	tokens := l.tokens
+	l.mu.Unlock()
+	if tokens < -0.1 {
+		t.Errorf("tokens after cancel = %v, want about 0", tokens)
+	}
+}
+
+func TestNewLimiterDisabled(t *testing.T) {
+	if l := newLimiter(nil, 0); l != nil {
+		t.Errorf("newLimiter(nil, 0) = %v, want nil", l)
+	}
+	if l := newLimiter(&RateLimit{}, 0); l != nil {
+		t.Errorf("newLimiter(zero rate, 0) = %v, want nil", l)
+	}
+	var l *limiter
+	release, err := l.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	if stats := l.stats(); stats != (LimiterStats{}) {
+		t.Errorf("nil limiter stats = %+v, want zero", stats)
+	}
+}
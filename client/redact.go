@@ -0,0 +1,126 @@
+// Package client provides the core HTTP client, authentication, and configuration for BSN.Cloud API.
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Redacted replaces secret values in logged headers and bodies.
+const Redacted = "[REDACTED]"
+
+// DefaultRedactFields are the JSON field names whose values are always masked in logs.
+// Names are matched case-insensitively, ignoring underscores and hyphens, so
+// "access_token" also matches "accessToken". They cover OAuth2 tokens and credentials,
+// local web server (LWS/LDWS) passwords and WiFi passphrases.
+var DefaultRedactFields = []string{
+	"password",
+	"passphrase",
+	"access_token",
+	"refresh_token",
+	"id_token",
+	"client_secret",
+	"secret",
+	"api_key",
+	"authorization",
+}
+
+// redactHeaders are the HTTP headers whose values are masked in logs.
+var redactHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// redactor masks secrets in data before it is logged.
+type redactor struct {
+	fields map[string]struct{}
+}
+
+// newRedactor returns a redactor for DefaultRedactFields plus extra field names.
+func newRedactor(extra []string) *redactor {
+	r := &redactor{fields: make(map[string]struct{}, len(DefaultRedactFields)+len(extra))}
+	for _, name := range DefaultRedactFields {
+		r.fields[normalizeField(name)] = struct{}{}
+	}
+	for _, name := range extra {
+		r.fields[normalizeField(name)] = struct{}{}
+	}
+	return r
+}
+
+// normalizeField folds a field name for matching: lower case, without '_' and '-'.
+func normalizeField(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+// headers returns a copy of h for logging with credential headers masked. The scheme of
+// an Authorization header is kept, e.g. "Bearer [REDACTED]".
+func (r *redactor) headers(h http.Header) map[string][]string {
+	out := make(map[string][]string, len(h))
+	for k, v := range h {
+		out[k] = v
+	}
+	for _, name := range redactHeaders {
+		values := h.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+		masked := make([]string, len(values))
+		for i, v := range values {
+			masked[i] = Redacted
+			if !strings.HasSuffix(name, "Authorization") {
+				continue
+			}
+			if scheme, _, ok := strings.Cut(v, " "); ok {
+				masked[i] = scheme + " " + Redacted
+			}
+		}
+		out[http.CanonicalHeaderKey(name)] = masked
+	}
+	return out
+}
+
+// body returns body for logging with the values of secret fields masked at any depth.
+// Bodies that are not JSON are not logged, only their size.
+func (r *redactor) body(body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return fmt.Sprintf("[non-JSON body, %d bytes]", len(body))
+	}
+	out, err := json.Marshal(r.value(v))
+	if err != nil {
+		return fmt.Sprintf("[unloggable body, %d bytes]", len(body))
+	}
+	return string(out)
+}
+
+// value masks secret fields in a decoded JSON value.
+func (r *redactor) value(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, field := range v {
+			if _, ok := r.fields[normalizeField(k)]; ok && field != nil && field != "" {
+				v[k] = Redacted
+				continue
+			}
+			v[k] = r.value(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = r.value(item)
+		}
+	}
+	return v
+}
+
+// RedactBody returns a JSON body for logging with secret fields masked, using the
+// client's redaction settings (DefaultRedactFields plus Config.RedactFields). Services
+// use it before logging API responses.
+func (c *Client) RedactBody(body []byte) string {
+	return c.redact.body(body)
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRedactHeaders(t *testing.T) {
+	h := http.Header{
+		"Authorization":       {"Bearer abc.def"},
+		"Proxy-Authorization": {"Basic dXNlcjpwYXNz"},
+		"Cookie":              {"session=1"},
+		"Set-Cookie":          {"a=1", "b=2"},
+		"X-Api-Token":         {"opaque"},
+		"Accept":              {"application/json"},
+	}
+	got := newRedactor(nil).headers(h)
+	want := map[string][]string{
+		"Authorization":       {"Bearer " + Redacted},
+		"Proxy-Authorization": {"Basic " + Redacted},
+		"Cookie":              {Redacted},
+		"Set-Cookie":          {Redacted, Redacted},
+		"X-Api-Token":         {"opaque"},
+		"Accept":              {"application/json"},
+	}
+	for k, v := range want {
+		if fmt.Sprint(got[k]) != fmt.Sprint(v) {
+			t.Errorf("headers()[%s] = %q, want %q", k, got[k], v)
+		}
+	}
+	if h.Get("Authorization") != "Bearer abc.def" {
+		t.Errorf("headers() modified its input: %q", h.Get("Authorization"))
+	}
+
+	// A credential without a scheme is masked entirely.
+	got = newRedactor(nil).headers(http.Header{"Authorization": {"abc"}})
+	if v := got["Authorization"]; len(v) != 1 || v[0] != Redacted {
+		t.Errorf("headers()[Authorization] = %q, want %q", v, Redacted)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name  string
+		extra []string
+		in    string
+		want  string
+	}{
+		{name: "empty", in: "", want: ""},
+		{name: "no secrets", in: `{"name":"Lobby","serial":"X1"}`, want: `{"name":"Lobby","serial":"X1"}`},
+		{name: "password", in: `{"username":"admin","password":"hunter2"}`, want: `{"password":"[REDACTED]","username":"admin"}`},
+		{
+			name: "nested LWS and WiFi",
+			in:   `{"settings":{"lws":{"password":"p1"},"network":{"interfaces":[{"security":{"authentication":{"mode":"WPA2","passphrase":"p2"}}}]}}}`,
+			want: `{"settings":{"lws":{"password":"[REDACTED]"},"network":{"interfaces":[{"security":{"authentication":{"mode":"WPA2","passphrase":"[REDACTED]"}}}]}}}`,
+		},
+		{name: "token spellings", in: `{"access_token":"a","refreshToken":"b","Client-Secret":"c"}`, want: `{"Client-Secret":"[REDACTED]","access_token":"[REDACTED]","refreshToken":"[REDACTED]"}`},
+		{name: "empty and null secrets kept", in: `{"password":"","passphrase":null}`, want: `{"passphrase":null,"password":""}`},
+		{name: "extra field", extra: []string{"serial"}, in: `{"serial":"X1","name":"Lobby"}`, want: `{"name":"Lobby","serial":"[REDACTED]"}`},
+		{name: "numbers preserved", in: `{"id":12345678901234567890,"secret":42}`, want: `{"id":12345678901234567890,"secret":"[REDACTED]"}`},
+		{name: "array", in: `[{"password":"x"},{"name":"y"}]`, want: `[{"password":"[REDACTED]"},{"name":"y"}]`},
+		{name: "not JSON", in: `password=hunter2`, want: `[non-JSON body, 16 bytes]`},
+		{name: "trailing data", in: `{"a":1}{"password":"x"}`, want: `[non-JSON body, 23 bytes]`},
+	}
+	for _, tt := range tests {
+		if got := newRedactor(tt.extra).body([]byte(tt.in)); got != tt.want {
+			t.Errorf("%s: body() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+// captureLogger records every log call's message and fields as text.
+type captureLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *captureLogger) Debug(msg string, fields ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprint(append([]any{msg}, fields...)...))
+}
+
+func (l *captureLogger) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return strings.Join(l.lines, "\n")
+}
+
+func TestDoRequestLogsAreRedacted(t *testing.T) {
+	ts := newTestServer(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"detail":null,"lws":{"password":"from-server"}}`))
+			return
+		}
+		w.Write([]byte(`{"lws":{"password":"from-server"}}`))
+	})
+	logger := &captureLogger{}
+	cfg := ts.config()
+	cfg.Logger = logger
+	cfg.RedactFields = []string{"ssid"}
+	c := New(cfg)
+	ctx := context.Background()
+
+	if _, err := c.DoRequest(ctx, "GET", "/self", nil); err != nil {
+		t.Fatal(err)
+	}
+	body := map[string]any{"lws": map[string]string{"password": "from-client"}, "ssid": "Lobby"}
+	_, err := c.DoRequest(ctx, "PUT", "/self", body)
+	if err == nil {
+		t.Fatal("DoRequest() error = nil, want 400")
+	}
+
+	logs := logger.String()
+	for _, secret := range []string{"tok-1", "from-client", "Lobby"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("logs contain %q:\n%s", secret, logs)
+		}
+	}
+	if !strings.Contains(logs, "Bearer "+Redacted) {
+		t.Errorf("logs do not show the masked Authorization header:\n%s", logs)
+	}
+	if msg := err.Error(); strings.Contains(msg, "from-server") || !strings.Contains(msg, Redacted) {
+		t.Errorf("APIError.Error() = %q, want the body redacted", msg)
+	}
+	if got := c.RedactBody([]byte(`{"ssid":"Lobby","password":"x"}`)); got != `{"password":"[REDACTED]","ssid":"[REDACTED]"}` {
+		t.Errorf("RedactBody() = %s", got)
+	}
+}
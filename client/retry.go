@@ -0,0 +1,161 @@
+// Package client provides the core HTTP client, authentication, and configuration for BSN.Cloud API.
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// DefaultRetryableStatusCodes are the HTTP statuses retried when RetryPolicy.RetryableStatusCodes is nil.
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy controls how DoRequest retries failed requests.
+// Only idempotent methods (GET, HEAD, OPTIONS, PUT, DELETE) are retried unless
+// RetryNonIdempotent is set.
+type RetryPolicy struct {
+	MaxAttempts          int              // Total attempts including the first; values below 2 disable retries
+	BaseBackoff          time.Duration    // Optional; delay before the first retry, doubled each attempt; if zero, 250ms is used
+	MaxBackoff           time.Duration    // Optional; upper bound on delays; a longer Retry-After ends retries; if zero, 10s is used
+	Jitter               float64          // Optional; fraction (0-1) of each delay that is randomised
+	RetryableStatusCodes []int            // Optional; if nil, DefaultRetryableStatusCodes is used
+	RetryableError       func(error) bool // Optional; reports whether a transport error is retryable; if nil, IsRetryableNetworkError is used
+	RetryNonIdempotent   bool             // Also retry POST and PATCH requests
+}
+
+// DefaultRetryPolicy returns a policy making up to 4 attempts with exponential backoff
+// from 250ms to 10s and 20% jitter.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		BaseBackoff: 250 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// shouldRetry reports whether a request that failed with err on the given attempt may be retried.
+func (p *RetryPolicy) shouldRetry(method string, attempt int, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(method) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		codes := p.RetryableStatusCodes
+		if codes == nil {
+			codes = DefaultRetryableStatusCodes
+		}
+		return slices.Contains(codes, apiErr.StatusCode)
+	}
+	retryable := p.RetryableError
+	if retryable == nil {
+		retryable = IsRetryableNetworkError
+	}
+	return retryable(err)
+}
+
+// backoff returns the delay before the retry following attempt. A Retry-After value
+// from the server takes precedence over the computed delay; if it is longer than
+// MaxBackoff, ok is false and the request should not be retried.
+func (p *RetryPolicy) backoff(attempt int, err error) (d time.Duration, ok bool) {
+	max := p.MaxBackoff
+	if max <= 0 {
+		max = 10 * time.Second
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter, apiErr.RetryAfter <= max
+	}
+	base := p.BaseBackoff
+	if base <= 0 {
+		base = 250 * time.Millisecond
+	}
+	d = base
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	if p.Jitter > 0 {
+		jitter := min(p.Jitter, 1)
+		d -= time.Duration(rand.Float64() * jitter * float64(d))
+	}
+	return d, true
+}
+
+// isIdempotent reports whether method is idempotent per RFC 9110.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// IsRetryableNetworkError reports whether err is a transient transport failure such as a
+// timeout, refused or reset connection, or a connection closed mid-response.
+// Context cancellation and deadline errors are never retryable, nor are DNS lookups
+// that found no such host.
+func IsRetryableNetworkError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// parseRetryAfter parses a Retry-After header given as seconds or an HTTP date.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
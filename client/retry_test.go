@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// flakyHandler answers the first failures API requests with status, then succeeds.
+func flakyHandler(failures int32, status int, header http.Header) http.HandlerFunc {
+	var calls atomic.Int32
+	return func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}
+}
+
+func TestDoRequestRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		status    int
+		header    http.Header
+		policy    *RetryPolicy
+		wantCalls int32
+		wantErr   int
+		minDelay  time.Duration
+	}{
+		{name: "502 GET retried", method: "GET", status: http.StatusBadGateway, wantCalls: 2},
+		{name: "502 DELETE retried", method: "DELETE", status: http.StatusBadGateway, wantCalls: 2},
+		{name: "502 POST not retried", method: "POST", status: http.StatusBadGateway, wantCalls: 1, wantErr: http.StatusBadGateway},
+		{
+			name:      "502 POST retried when allowed",
+			method:    "POST",
+			status:    http.StatusBadGateway,
+			policy:    &RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, RetryNonIdempotent: true},
+			wantCalls: 2,
+		},
+		{name: "500 not retried", method: "GET", status: http.StatusInternalServerError, wantCalls: 1, wantErr: http.StatusInternalServerError},
+		{name: "404 not retried", method: "GET", status: http.StatusNotFound, wantCalls: 1, wantErr: http.StatusNotFound},
+		{
+			name:      "429 waits for Retry-After",
+			method:    "GET",
+			status:    http.StatusTooManyRequests,
+			header:    http.Header{"Retry-After": {"1"}},
+			wantCalls: 2,
+			minDelay:  time.Second,
+		},
+		{
+			name:      "Retry-After beyond MaxBackoff not retried",
+			method:    "GET",
+			status:    http.StatusTooManyRequests,
+			header:    http.Header{"Retry-After": {"86400"}},
+			wantCalls: 1,
+			wantErr:   http.StatusTooManyRequests,
+		},
+		{
+			name:      "no policy",
+			method:    "GET",
+			status:    http.StatusBadGateway,
+			policy:    &RetryPolicy{},
+			wantCalls: 1,
+			wantErr:   http.StatusBadGateway,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t, 0, flakyHandler(1, tt.status, tt.header))
+			cfg := ts.config()
+			cfg.Retry = tt.policy
+			if cfg.Retry == nil {
+				cfg.Retry = &RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, MaxBackoff: 2 * time.Second}
+			}
+			c := New(cfg)
+
+			start := time.Now()
+			_, err := c.DoRequest(context.Background(), tt.method, "/self", nil)
+			elapsed := time.Since(start)
+			if got := StatusCode(err); got != tt.wantErr {
+				t.Errorf("DoRequest() error = %v, want status %d", err, tt.wantErr)
+			}
+			if n := ts.apiCalls.Load(); n != tt.wantCalls {
+				t.Errorf("API requests = %d, want %d", n, tt.wantCalls)
+			}
+			if elapsed < tt.minDelay {
+				t.Errorf("DoRequest() took %v, want at least %v", elapsed, tt.minDelay)
+			}
+		})
+	}
+}
+
+func TestDoRequestRetryStopsAtMaxAttempts(t *testing.T) {
+	ts := newTestServer(t, 0, flakyHandler(10, http.StatusServiceUnavailable, nil))
+	cfg := ts.config()
+	cfg.Retry = &RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond}
+	c := New(cfg)
+
+	_, err := c.DoRequest(context.Background(), "GET", "/self", nil)
+	if StatusCode(err) != http.StatusServiceUnavailable {
+		t.Errorf("DoRequest() error = %v, want 503", err)
+	}
+	if n := ts.apiCalls.Load(); n != 3 {
+		t.Errorf("API requests = %d, want 3", n)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := &RetryPolicy{BaseBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	tests := []struct {
+		attempt int
+		err     error
+		want    time.Duration
+		wantOK  bool
+	}{
+		{attempt: 1, want: 100 * time.Millisecond, wantOK: true},
+		{attempt: 2, want: 200 * time.Millisecond, wantOK: true},
+		{attempt: 3, want: 400 * time.Millisecond, wantOK: true},
+		{attempt: 5, want: time.Second, wantOK: true},
+		{attempt: 50, want: time.Second, wantOK: true},
+		{attempt: 1, err: &APIError{RetryAfter: 500 * time.Millisecond}, want: 500 * time.Millisecond, wantOK: true},
+		{attempt: 1, err: &APIError{RetryAfter: time.Second}, want: time.Second, wantOK: true},
+		{attempt: 1, err: fmt.Errorf("wrapped: %w", &APIError{RetryAfter: time.Hour}), want: time.Hour, wantOK: false},
+	}
+	for _, tt := range tests {
+		got, ok := p.backoff(tt.attempt, tt.err)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("backoff(%d, %v) = %v, %v; want %v, %v", tt.attempt, tt.err, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	jittered := &RetryPolicy{BaseBackoff: 100 * time.Millisecond, Jitter: 0.5}
+	for range 100 {
+		if d, _ := jittered.backoff(1, nil); d < 50*time.Millisecond || d > 100*time.Millisecond {
+			t.Fatalf("jittered backoff = %v, want between 50ms and 100ms", d)
+		}
+	}
+}
+
+func TestIsRetryableNetworkError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "canceled", err: context.Canceled, want: false},
+		{name: "deadline", err: fmt.Errorf("get: %w", context.DeadlineExceeded), want: false},
+		{name: "unexpected EOF", err: io.ErrUnexpectedEOF, want: true},
+		{name: "connection refused", err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, want: true},
+		{name: "connection reset", err: &net.OpError{Op: "read", Err: syscall.ECONNRESET}, want: true},
+		{name: "timeout", err: &net.DNSError{Err: "timeout", IsTimeout: true}, want: true},
+		{name: "temporary DNS failure", err: &net.DNSError{Err: "server misbehaving", IsTemporary: true}, want: true},
+		{name: "no such host", err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "api.invalid", IsNotFound: true}}, want: false},
+		{name: "other dial error", err: &net.OpError{Op: "dial", Err: errors.New("network is unreachable")}, want: false},
+		{name: "other", err: errors.New("boom"), want: false},
+	}
+	for _, tt := range tests {
+		if got := IsRetryableNetworkError(tt.err); got != tt.want {
+			t.Errorf("%s: IsRetryableNetworkError(%v) = %v, want %v", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{in: "", want: 0},
+		{in: "0", want: 0},
+		{in: "-5", want: 0},
+		{in: "120", want: 2 * time.Minute},
+		{in: "soon", want: 0},
+		{in: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.in); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(future); got < 59*time.Minute || got > time.Hour {
+		t.Errorf("parseRetryAfter(%q) = %v, want about 1h", future, got)
+	}
+}
@@ -0,0 +1,84 @@
+// Package client provides the core HTTP client, authentication, and configuration for BSN.Cloud API.
+package client
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Operation names reported to Instrumentation.
+const (
+	OperationAuthenticate  = "Authenticate"  // Obtaining a new access token, including network selection
+	OperationSelectNetwork = "SelectNetwork" // Selecting the session network
+	OperationRequest       = "DoRequest"     // A DoRequest call, including retries
+)
+
+// Instrumentation receives telemetry from the client, such as tracing spans and metrics.
+// It has no dependencies so the client does not force any telemetry SDK on its users;
+// an implementation can report to OpenTelemetry or any other backend. Implementations
+// must be safe for concurrent use.
+type Instrumentation interface {
+	// StartOperation is called when an operation begins. The returned context is used for
+	// the operation's HTTP calls, and end is called once with the outcome.
+	StartOperation(ctx context.Context, op Operation) (_ context.Context, end func(Outcome))
+}
+
+// Operation describes an operation reported to Instrumentation.
+type Operation struct {
+	Name     string // One of the Operation* constants
+	Method   string // HTTP method, for OperationRequest
+	Endpoint string // Endpoint template with ids replaced, e.g. "/Devices/{id}/", for OperationRequest
+	Network  string // Network name configured on the client, if any
+}
+
+// Outcome describes how an operation ended.
+type Outcome struct {
+	StatusCode int           // Final HTTP status, or 0 if no response was received
+	Attempts   int           // HTTP attempts made, including retries and the replay after a 401
+	Duration   time.Duration // Wall time of the operation
+	Err        error         // Error returned by the operation, if any
+}
+
+// startOperation reports the start of op to the configured Instrumentation. The returned
+// end function records the outcome; it is a no-op when instrumentation is disabled.
+func (c *Client) startOperation(ctx context.Context, op Operation) (context.Context, func(*Outcome)) {
+	if c.instr == nil {
+		return ctx, func(*Outcome) {}
+	}
+	op.Network = c.network
+	start := time.Now()
+	ctx, end := c.instr.StartOperation(ctx, op)
+	return ctx, func(out *Outcome) {
+		out.Duration = time.Since(start)
+		if out.StatusCode == 0 {
+			out.StatusCode = StatusCode(out.Err)
+		}
+		end(*out)
+	}
+}
+
+type endpointTemplateKey struct{}
+
+// WithEndpointTemplate returns a context that reports tmpl as the endpoint template of
+// DoRequest calls made with it, for endpoints whose variable parts are not numeric ids,
+// e.g. "/self/networks/{name}/".
+func WithEndpointTemplate(ctx context.Context, tmpl string) context.Context {
+	return context.WithValue(ctx, endpointTemplateKey{}, tmpl)
+}
+
+// endpointTemplate returns the template for endpoint: the one set with WithEndpointTemplate,
+// or endpoint without its query string and with numeric path segments replaced by "{id}".
+func endpointTemplate(ctx context.Context, endpoint string) string {
+	if tmpl, ok := ctx.Value(endpointTemplateKey{}).(string); ok {
+		return tmpl
+	}
+	path, _, _ := strings.Cut(endpoint, "?")
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if seg != "" && strings.Trim(seg, "0123456789") == "" {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
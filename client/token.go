@@ -0,0 +1,185 @@
+// Package client provides the core HTTP client, authentication, and configuration for BSN.Cloud API.
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/carrier-labs/go-bsn-cloud-client/debug"
+)
+
+// GrantType selects the OAuth2 grant used by the default TokenSource.
+type GrantType string
+
+const (
+	// GrantClientCredentials authenticates as the API client using ClientID and ClientSecret.
+	GrantClientCredentials GrantType = "client_credentials"
+	// GrantPassword authenticates as a user with Username and Password (resource-owner password grant).
+	GrantPassword GrantType = "password"
+)
+
+// Token is an OAuth2 access token. Field names and JSON tags follow golang.org/x/oauth2.Token.
+type Token struct {
+	AccessToken   string    `json:"access_token"`             // Bearer token sent with API requests
+	TokenType     string    `json:"token_type,omitempty"`     // Token type, usually "Bearer"
+	RefreshToken  string    `json:"refresh_token,omitempty"`  // Refresh token, if the grant issued one
+	Expiry        time.Time `json:"expiry,omitempty"`         // When the access token expires; zero means never
+	RefreshExpiry time.Time `json:"refresh_expiry,omitempty"` // When the refresh token expires; zero means unknown
+}
+
+// Valid reports whether t is non-nil, has an access token and is not within 30s of expiry.
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Before(t.Expiry.Add(-expirySkew))
+}
+
+// CanRefresh reports whether t has a refresh token that has not expired.
+func (t *Token) CanRefresh() bool {
+	if t == nil || t.RefreshToken == "" {
+		return false
+	}
+	return t.RefreshExpiry.IsZero() || time.Now().Before(t.RefreshExpiry.Add(-expirySkew))
+}
+
+// TokenSource supplies access tokens. It mirrors golang.org/x/oauth2.TokenSource, with a
+// context so token requests can be cancelled. Client caches the returned token and only
+// asks the source again once it expires or is rejected.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenRefresher is implemented by TokenSources that can exchange a refresh token for a
+// new access token. Client prefers Refresh over Token while the refresh token is valid,
+// and falls back to Token if the refresh fails.
+type TokenRefresher interface {
+	Refresh(ctx context.Context, t *Token) (*Token, error)
+}
+
+// TokenSourceFunc adapts an ordinary function to a TokenSource.
+type TokenSourceFunc func(ctx context.Context) (*Token, error)
+
+// Token calls f(ctx).
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// tokenResponse is the token endpoint response body.
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	TokenType        string `json:"token_type"`
+	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiresIn int    `json:"refresh_expires_in"`
+}
+
+// oauthClient posts grant requests to the token endpoint.
+type oauthClient struct {
+	httpClient   *http.Client
+	endpoint     *tokenEndpoint
+	clientID     string
+	clientSecret string
+	log          debug.LeveledLogger
+	redact       *redactor
+}
+
+// requestToken posts form to the token endpoint and returns the issued token. Confidential
+// clients authenticate with HTTP Basic; public clients (no secret) send client_id in the form.
+func (o *oauthClient) requestToken(ctx context.Context, form url.Values) (*Token, error) {
+	tokenURL, err := o.endpoint.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if o.clientSecret == "" && o.clientID != "" {
+		form.Set("client_id", o.clientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if o.clientSecret != "" {
+		auth := base64.StdEncoding.EncodeToString([]byte(o.clientID + ":" + o.clientSecret))
+		req.Header.Set("Authorization", "Basic "+auth)
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	o.log.Debug("API call", "method", req.Method, "url", req.URL.String(), "grant_type", form.Get("grant_type"))
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		o.log.Debug("API error response", "status", resp.Status, "body", o.redact.body(body))
+		return nil, fmt.Errorf("auth failed: %w", newAPIError(resp, req.Method, tokenURL, body, o.redact))
+	}
+
+	var tr tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	t := &Token{
+		AccessToken:  tr.AccessToken,
+		TokenType:    tr.TokenType,
+		RefreshToken: tr.RefreshToken,
+		Expiry:       now.Add(time.Duration(tr.ExpiresIn) * time.Second),
+	}
+	if tr.RefreshExpiresIn > 0 {
+		t.RefreshExpiry = now.Add(time.Duration(tr.RefreshExpiresIn) * time.Second)
+	}
+	return t, nil
+}
+
+// clientCredentialsSource fetches tokens with the OAuth2 client_credentials grant.
+type clientCredentialsSource struct {
+	oauthClient
+}
+
+// Token requests a new access token from the auth server.
+func (s *clientCredentialsSource) Token(ctx context.Context) (*Token, error) {
+	return s.requestToken(ctx, url.Values{"grant_type": {string(GrantClientCredentials)}})
+}
+
+// passwordSource fetches tokens with the OAuth2 resource-owner password grant and
+// renews them with the refresh_token grant.
+type passwordSource struct {
+	oauthClient
+	username string
+	password string
+}
+
+// Token logs in with the user's credentials.
+func (s *passwordSource) Token(ctx context.Context) (*Token, error) {
+	return s.requestToken(ctx, url.Values{
+		"grant_type": {string(GrantPassword)},
+		"username":   {s.username},
+		"password":   {s.password},
+	})
+}
+
+// Refresh exchanges t's refresh token for a new access token. If the server does not
+// rotate the refresh token, the previous one is kept.
+func (s *passwordSource) Refresh(ctx context.Context, t *Token) (*Token, error) {
+	next, err := s.requestToken(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {t.RefreshToken},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if next.RefreshToken == "" {
+		next.RefreshToken, next.RefreshExpiry = t.RefreshToken, t.RefreshExpiry
+	}
+	return next, nil
+}
@@ -0,0 +1,153 @@
+// Package client provides the core HTTP client, authentication, and configuration for BSN.Cloud API.
+package client
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// TokenCache persists tokens between processes so short-lived programs can reuse a
+// valid token instead of authenticating on every run. Keys are opaque hex strings
+// derived from the client ID, grant type, user name, auth server, API and network a
+// token belongs to.
+type TokenCache interface {
+	// Load returns the cached token for key, or nil if there is none.
+	Load(ctx context.Context, key string) (*Token, error)
+	// Save stores t under key.
+	Save(ctx context.Context, key string, t *Token) error
+	// Delete removes any token stored under key.
+	Delete(ctx context.Context, key string) error
+}
+
+// FileTokenCache is a TokenCache storing one file per key in a directory.
+// Files are written with mode 0600. If Key is set, entries are encrypted with
+// AES-256-GCM using a key derived from it.
+type FileTokenCache struct {
+	Dir string // Directory holding cache files; created with mode 0700 if missing
+	Key []byte // Optional; secret used to encrypt entries at rest
+}
+
+// DefaultTokenCacheDir returns the directory used for cached tokens under the user's cache directory.
+func DefaultTokenCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "go-bsn-cloud-client", "tokens"), nil
+}
+
+// Load reads and, if needed, decrypts the token stored under key.
+func (f *FileTokenCache) Load(_ context.Context, key string) (*Token, error) {
+	data, err := os.ReadFile(f.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if f.Key != nil {
+		if data, err = f.decrypt(key, data); err != nil {
+			return nil, fmt.Errorf("decrypting cached token: %w", err)
+		}
+	}
+	var t Token
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("parsing cached token: %w", err)
+	}
+	return &t, nil
+}
+
+// Save writes t under key, replacing any previous entry atomically.
+func (f *FileTokenCache) Save(_ context.Context, key string, t *Token) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	if f.Key != nil {
+		if data, err = f.encrypt(key, data); err != nil {
+			return fmt.Errorf("encrypting token: %w", err)
+		}
+	}
+	if err := os.MkdirAll(f.Dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(f.Dir, ".token-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path(key))
+}
+
+// Delete removes the entry for key. Deleting a missing entry is not an error.
+func (f *FileTokenCache) Delete(_ context.Context, key string) error {
+	err := os.Remove(f.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path returns the file for key. Keys are hashed so they are safe as file names and
+// do not reveal client IDs or network names.
+func (f *FileTokenCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.Dir, hex.EncodeToString(sum[:16])+".token")
+}
+
+// aead returns the AES-256-GCM cipher for the cache key.
+func (f *FileTokenCache) aead() (cipher.AEAD, error) {
+	k := sha256.Sum256(f.Key)
+	block, err := aes.NewCipher(k[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt seals data with a random nonce, binding it to key as additional data.
+func (f *FileTokenCache) encrypt(key string, data []byte) ([]byte, error) {
+	gcm, err := f.aead()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, data, []byte(key)), nil
+}
+
+// decrypt opens data produced by encrypt.
+func (f *FileTokenCache) decrypt(key string, data []byte) ([]byte, error) {
+	gcm, err := f.aead()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, sealed := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, sealed, []byte(key))
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileTokenCache(t *testing.T) {
+	token := &Token{
+		AccessToken:  "access-secret",
+		TokenType:    "Bearer",
+		RefreshToken: "refresh-secret",
+		Expiry:       time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	tests := []struct {
+		name string
+		key  []byte
+	}{
+		{name: "plain"},
+		{name: "encrypted", key: []byte("cache key")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cache := &FileTokenCache{Dir: filepath.Join(t.TempDir(), "tokens"), Key: tt.key}
+
+			if got, err := cache.Load(ctx, "k"); got != nil || err != nil {
+				t.Fatalf("Load() of missing entry = %v, %v; want nil, nil", got, err)
+			}
+			if err := cache.Save(ctx, "k", token); err != nil {
+				t.Fatal(err)
+			}
+			got, err := cache.Load(ctx, "k")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != *token {
+				t.Errorf("Load() = %+v, want %+v", got, token)
+			}
+
+			info, err := os.Stat(cache.path("k"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if perm := info.Mode().Perm(); perm != 0o600 {
+				t.Errorf("file mode = %v, want 0600", perm)
+			}
+			data, _ := os.ReadFile(cache.path("k"))
+			if encrypted := !bytes.Contains(data, []byte("access-secret")); encrypted != (tt.key != nil) {
+				t.Errorf("file encrypted = %v, want %v", encrypted, tt.key != nil)
+			}
+
+			if got, err := cache.Load(ctx, "other"); got != nil || err != nil {
+				t.Errorf("Load() of other key = %v, %v; want nil, nil", got, err)
+			}
+			if err := cache.Delete(ctx, "k"); err != nil {
+				t.Fatal(err)
+			}
+			if got, err := cache.Load(ctx, "k"); got != nil || err != nil {
+				t.Errorf("Load() after Delete = %v, %v; want nil, nil", got, err)
+			}
+			if err := cache.Delete(ctx, "k"); err != nil {
+				t.Errorf("Delete() of missing entry error: %v", err)
+			}
+		})
+	}
+}
+
+func TestFileTokenCacheWrongKey(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	token := &Token{AccessToken: "access-secret"}
+	if err := (&FileTokenCache{Dir: dir, Key: []byte("right")}).Save(ctx, "k", token); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		cache *FileTokenCache
+	}{
+		{name: "wrong key", cache: &FileTokenCache{Dir: dir, Key: []byte("wrong")}},
+		{name: "no key", cache: &FileTokenCache{Dir: dir}},
+	}
+	for _, tt := range tests {
+		if got, err := tt.cache.Load(ctx, "k"); err == nil {
+			t.Errorf("%s: Load() = %+v, want error", tt.name, got)
+		}
+	}
+
+	// An entry copied to another key's file does not decrypt, as the key is bound in.
+	cache := &FileTokenCache{Dir: dir, Key: []byte("right")}
+	data, err := os.ReadFile(cache.path("k"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cache.path("moved"), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := cache.Load(ctx, "moved"); err == nil {
+		t.Errorf("Load() of moved entry = %+v, want error", got)
+	}
+}
+
+func TestClientUsesTokenCache(t *testing.T) {
+	ts := newTestServer(t, 0, okHandler)
+	ctx := context.Background()
+	cfg := ts.config()
+	cfg.TokenCache = &FileTokenCache{Dir: t.TempDir(), Key: []byte("cache key")}
+
+	if err := New(cfg).Authenticate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	c := New(cfg)
+	if err := c.Authenticate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if token, _ := c.Token(); token != "tok-1" {
+		t.Errorf("Token() = %q, want cached tok-1", token)
+	}
+	if n := ts.tokenCalls.Load(); n != 1 {
+		t.Errorf("token requests = %d, want 1", n)
+	}
+}
+
+func TestCacheKeySeparatesCredentials(t *testing.T) {
+	base := Config{ClientID: "public", Username: "alice", AuthURL: "https://auth.example.com/token", BaseAPI: DefaultBaseAPI, NetworkName: "Lobby"}
+	variants := map[string]func(*Config){
+		"user":       func(c *Config) { c.Username = "bob" },
+		"grant":      func(c *Config) { c.GrantType = GrantClientCredentials },
+		"auth URL":   func(c *Config) { c.AuthURL = "https://other.example.com/token" },
+		"issuer":     func(c *Config) { c.AuthURL, c.Issuer = "", "https://auth.example.com" },
+		"client":     func(c *Config) { c.ClientID = "other" },
+		"base API":   func(c *Config) { c.BaseAPI = "https://api.example.com" },
+		"network":    func(c *Config) { c.NetworkName = "Office" },
+		"no network": func(c *Config) { c.NetworkName = "" },
+	}
+	key := New(base).cacheKey()
+	if strings.Contains(key, "alice") || strings.Contains(key, "public") {
+		t.Errorf("cacheKey() = %q, want it hashed", key)
+	}
+	if again := New(base).cacheKey(); again != key {
+		t.Errorf("cacheKey() = %q then %q, want stable", key, again)
+	}
+	for name, change := range variants {
+		cfg := base
+		change(&cfg)
+		if New(cfg).cacheKey() == key {
+			t.Errorf("%s: cacheKey() unchanged", name)
+		}
+	}
+
+	// The default grant resolves the same whether or not it is spelled out.
+	implicit := Config{ClientID: "confidential", ClientSecret: "s"}
+	explicit := implicit
+	explicit.GrantType = GrantClientCredentials
+	if New(implicit).cacheKey() != New(explicit).cacheKey() {
+		t.Error("cacheKey() differs for the implicit and explicit client_credentials grant")
+	}
+	office := base
+	office.NetworkName = "Office"
+	if New(base).WithNetwork("Office").cacheKey() != New(office).cacheKey() {
+		t.Error("cacheKey() of WithNetwork differs from a client configured for that network")
+	}
+}
+
+func TestClientTokenCacheIsPerUser(t *testing.T) {
+	ts := newTestServer(t, 0, okHandler)
+	ctx := context.Background()
+	cache := &FileTokenCache{Dir: t.TempDir()}
+	alice := Config{ClientID: "public", Username: "alice", Password: "a", AuthURL: ts.URL + "/token", BaseAPI: ts.URL, TokenCache: cache}
+	bob := alice
+	bob.Username, bob.Password = "bob", "b"
+
+	if err := New(alice).Authenticate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	c := New(bob)
+	if err := c.Authenticate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if token, _ := c.Token(); token != "tok-2" {
+		t.Errorf("bob's Token() = %q, want a new tok-2, not alice's", token)
+	}
+}
@@ -1,26 +1,40 @@
 // Package bsndebug provides a generic, pluggable logger for the go-bsn-cloud-client.
 package debug
 
-import "sync"
+import (
+	"slices"
+	"sync"
+)
 
 // Logger is a minimal interface for debug logging.
 type Logger interface {
 	Debug(msg string, fields ...any)
 }
 
+// LeveledLogger is a Logger that also logs at info, warning and error levels.
+// Fields are alternating key/value pairs, as with log/slog.
+type LeveledLogger interface {
+	Logger
+	Info(msg string, fields ...any)
+	Warn(msg string, fields ...any)
+	Error(msg string, fields ...any)
+}
+
 var (
-	logger Logger = noopLogger{}
+	logger LeveledLogger = noopLogger{}
 	mu     sync.RWMutex
 )
 
-// SetLogger sets the package-level logger. Pass nil to disable logging.
+// SetLogger sets the package-level logger used by clients without their own logger.
+// Loggers that only implement Logger receive all levels through Debug. Pass nil to
+// disable logging.
 func SetLogger(l Logger) {
 	mu.Lock()
 	defer mu.Unlock()
 	if l == nil {
 		logger = noopLogger{}
 	} else {
-		logger = l
+		logger = Leveled(l)
 	}
 }
 
@@ -31,6 +45,71 @@ func Debug(msg string, fields ...any) {
 	logger.Debug(msg, fields...)
 }
 
+// Info logs an informational message using the configured logger.
+func Info(msg string, fields ...any) {
+	mu.RLock()
+	defer mu.RUnlock()
+	logger.Info(msg, fields...)
+}
+
+// Warn logs a warning using the configured logger.
+func Warn(msg string, fields ...any) {
+	mu.RLock()
+	defer mu.RUnlock()
+	logger.Warn(msg, fields...)
+}
+
+// Error logs an error using the configured logger.
+func Error(msg string, fields ...any) {
+	mu.RLock()
+	defer mu.RUnlock()
+	logger.Error(msg, fields...)
+}
+
+// Global returns a LeveledLogger that forwards to the package-level logger, following
+// later SetLogger calls.
+func Global() LeveledLogger {
+	return globalLogger{}
+}
+
+// Leveled returns l as a LeveledLogger. If l only implements Logger, info, warning and
+// error messages are sent to its Debug method with a "level" field. A nil l returns a
+// logger that discards everything.
+func Leveled(l Logger) LeveledLogger {
+	switch v := l.(type) {
+	case nil:
+		return noopLogger{}
+	case LeveledLogger:
+		return v
+	default:
+		return debugOnlyLogger{l}
+	}
+}
+
+type globalLogger struct{}
+
+func (globalLogger) Debug(msg string, fields ...any) { Debug(msg, fields...) }
+func (globalLogger) Info(msg string, fields ...any)  { Info(msg, fields...) }
+func (globalLogger) Warn(msg string, fields ...any)  { Warn(msg, fields...) }
+func (globalLogger) Error(msg string, fields ...any) { Error(msg, fields...) }
+
+type debugOnlyLogger struct{ Logger }
+
+func (l debugOnlyLogger) Info(msg string, fields ...any) {
+	l.Debug(msg, append(slices.Clip(fields), "level", "info")...)
+}
+
+func (l debugOnlyLogger) Warn(msg string, fields ...any) {
+	l.Debug(msg, append(slices.Clip(fields), "level", "warn")...)
+}
+
+func (l debugOnlyLogger) Error(msg string, fields ...any) {
+	l.Debug(msg, append(slices.Clip(fields), "level", "error")...)
+}
+
 type noopLogger struct{}
 
 func (noopLogger) Debug(string, ...any) {}
+func (noopLogger) Info(string, ...any)  {}
+func (noopLogger) Warn(string, ...any)  {}
+func (noopLogger) Error(string, ...any) {}
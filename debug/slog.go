@@ -0,0 +1,50 @@
+// Package bsndebug provides a generic, pluggable logger for the go-bsn-cloud-client.
+// This file provides an adapter for log/slog.
+package debug
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogLogger adapts a slog.Logger to the bsndebug.LeveledLogger interface.
+// Fields are passed to slog unchanged, so slog.Attr values may be used as well as
+// key/value pairs.
+type SlogLogger struct {
+	L *slog.Logger
+}
+
+// NewSlogLogger returns a SlogLogger writing to l, or to slog.Default() if l is nil.
+func NewSlogLogger(l *slog.Logger) *SlogLogger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return &SlogLogger{L: l}
+}
+
+// Debug logs a debug message with optional fields.
+func (s *SlogLogger) Debug(msg string, fields ...any) {
+	s.log(slog.LevelDebug, msg, fields)
+}
+
+// Info logs an informational message with optional fields.
+func (s *SlogLogger) Info(msg string, fields ...any) {
+	s.log(slog.LevelInfo, msg, fields)
+}
+
+// Warn logs a warning with optional fields.
+func (s *SlogLogger) Warn(msg string, fields ...any) {
+	s.log(slog.LevelWarn, msg, fields)
+}
+
+// Error logs an error with optional fields.
+func (s *SlogLogger) Error(msg string, fields ...any) {
+	s.log(slog.LevelError, msg, fields)
+}
+
+func (s *SlogLogger) log(level slog.Level, msg string, fields []any) {
+	if s.L == nil {
+		return
+	}
+	s.L.Log(context.Background(), level, msg, fields...)
+}
@@ -6,7 +6,7 @@ import (
 	"go.uber.org/zap"
 )
 
-// ZapLogger adapts a zap.Logger to the bsndebug.Logger interface.
+// ZapLogger adapts a zap.Logger to the bsndebug.LeveledLogger interface.
 type ZapLogger struct {
 	L *zap.Logger
 }
@@ -16,13 +16,42 @@ func (z *ZapLogger) Debug(msg string, fields ...any) {
 	if z.L == nil {
 		return
 	}
-	zapFields := make([]zap.Field, 0, len(fields)/2)
+	z.L.Debug(msg, zapFields(fields)...)
+}
+
+// Info logs an informational message with optional fields.
+func (z *ZapLogger) Info(msg string, fields ...any) {
+	if z.L == nil {
+		return
+	}
+	z.L.Info(msg, zapFields(fields)...)
+}
+
+// Warn logs a warning with optional fields.
+func (z *ZapLogger) Warn(msg string, fields ...any) {
+	if z.L == nil {
+		return
+	}
+	z.L.Warn(msg, zapFields(fields)...)
+}
+
+// Error logs an error with optional fields.
+func (z *ZapLogger) Error(msg string, fields ...any) {
+	if z.L == nil {
+		return
+	}
+	z.L.Error(msg, zapFields(fields)...)
+}
+
+// zapFields converts alternating key/value pairs to zap fields, skipping non-string keys.
+func zapFields(fields []any) []zap.Field {
+	zf := make([]zap.Field, 0, len(fields)/2)
 	for i := 0; i+1 < len(fields); i += 2 {
 		key, ok := fields[i].(string)
 		if !ok {
 			continue
 		}
-		zapFields = append(zapFields, zap.Any(key, fields[i+1]))
+		zf = append(zf, zap.Any(key, fields[i+1]))
 	}
-	z.L.Debug(msg, zapFields...)
+	return zf
 }
@@ -3,7 +3,11 @@ package models
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 )
 
 // PlayerBeaconMode is an enum for beacon modes supported by BSN.Cloud.
@@ -20,6 +24,38 @@ const (
 	PlayerBeaconModeUnknown PlayerBeaconMode = "Unknown"
 )
 
+var playerBeaconModeValues = []PlayerBeaconMode{
+	PlayerBeaconModeIBeacon,
+	PlayerBeaconModeEddystoneUid,
+	PlayerBeaconModeEddystoneUrl,
+}
+
+// PlayerBeaconModeValues returns the defined values, excluding PlayerBeaconModeUnknown.
+func PlayerBeaconModeValues() []PlayerBeaconMode {
+	return slices.Clone(playerBeaconModeValues)
+}
+
+// ParsePlayerBeaconMode returns the PlayerBeaconMode matching s, ignoring case.
+// For other values it returns PlayerBeaconMode(s) and an *UnknownEnumError.
+func ParsePlayerBeaconMode(s string) (PlayerBeaconMode, error) {
+	return parseEnum("PlayerBeaconMode", s, playerBeaconModeValues)
+}
+
+// IsKnown reports whether v is one of PlayerBeaconModeValues.
+func (v PlayerBeaconMode) IsKnown() bool {
+	return isKnownEnum(v, playerBeaconModeValues)
+}
+
+// UnmarshalJSON decodes v according to the current EnumPolicy.
+func (v *PlayerBeaconMode) UnmarshalJSON(data []byte) error {
+	parsed, err := unmarshalEnum(data, "PlayerBeaconMode", playerBeaconModeValues, PlayerBeaconModeUnknown)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
 // DeviceBeacon is an interface for all beacon types supported by BSN.Cloud.
 type DeviceBeacon interface {
 	// GetMode returns the beacon mode for this beacon.
@@ -62,25 +98,43 @@ type EddystoneUrlBeacon struct {
 // GetMode returns the beacon mode for EddystoneUrlBeacon (always EddystoneUrl).
 func (b EddystoneUrlBeacon) GetMode() PlayerBeaconMode { return b.Mode }
 
-// DeviceBeaconWrapper is used for custom unmarshalling of DeviceBeacon sum types.
+// UnknownBeacon holds a beacon of a mode this package does not model. The original
+// JSON is kept so the beacon survives a read-modify-write round-trip.
+type UnknownBeacon struct {
+	Mode PlayerBeaconMode // Beacon mode as sent by the API
+	Raw  json.RawMessage  // Original JSON object
+}
+
+// GetMode returns the beacon mode as sent by the API.
+func (b UnknownBeacon) GetMode() PlayerBeaconMode { return b.Mode }
+
+// MarshalJSON writes the original JSON, or just the mode if there is none.
+func (b UnknownBeacon) MarshalJSON() ([]byte, error) {
+	if len(b.Raw) > 0 {
+		return b.Raw, nil
+	}
+	return json.Marshal(struct {
+		Mode PlayerBeaconMode `json:"mode"`
+	}{b.Mode})
+}
+
+// DeviceBeaconWrapper is used for custom marshalling of DeviceBeacon sum types.
 type DeviceBeaconWrapper struct {
 	DeviceBeacon
 }
 
 // UnmarshalJSON implements custom unmarshalling for DeviceBeaconWrapper.
 // It determines the beacon type by the "mode" field and unmarshals into the correct struct.
+// Beacons of unrecognised modes are kept as UnknownBeacon, or rejected under EnumStrict.
 func (w *DeviceBeaconWrapper) UnmarshalJSON(data []byte) error {
-	var raw map[string]interface{}
-	if err := json.Unmarshal(data, &raw); err != nil {
-		return err
+	var modeProbe struct {
+		Mode PlayerBeaconMode `json:"mode"`
 	}
-
-	typeVal, ok := raw["mode"].(string)
-	if !ok {
-		return fmt.Errorf("missing or invalid mode field in DeviceBeacon")
+	if err := json.Unmarshal(data, &modeProbe); err != nil {
+		return fmt.Errorf("invalid DeviceBeacon: %w", err)
 	}
 
-	switch PlayerBeaconMode(typeVal) {
+	switch modeProbe.Mode {
 	case PlayerBeaconModeIBeacon:
 		var beacon IBeacon
 		if err := json.Unmarshal(data, &beacon); err != nil {
@@ -100,11 +154,51 @@ func (w *DeviceBeaconWrapper) UnmarshalJSON(data []byte) error {
 		}
 		w.DeviceBeacon = beacon
 	default:
-		return fmt.Errorf("unknown beacon mode: %s", typeVal)
+		w.DeviceBeacon = UnknownBeacon{Mode: modeProbe.Mode, Raw: append(json.RawMessage(nil), data...)}
 	}
 	return nil
 }
 
+// MarshalJSON implements custom marshalling for DeviceBeaconWrapper. Beacons without a
+// mode are given the mode matching their concrete struct.
+func (w DeviceBeaconWrapper) MarshalJSON() ([]byte, error) {
+	return json.Marshal(withBeaconMode(w.DeviceBeacon))
+}
+
+// withBeaconMode returns b with its Mode set from its concrete struct if it is empty.
+func withBeaconMode(b DeviceBeacon) DeviceBeacon {
+	switch v := b.(type) {
+	case IBeacon:
+		if v.Mode == "" {
+			v.Mode = PlayerBeaconModeIBeacon
+		}
+		return v
+	case *IBeacon:
+		if v != nil {
+			return withBeaconMode(*v)
+		}
+	case EddystoneUidBeacon:
+		if v.Mode == "" {
+			v.Mode = PlayerBeaconModeEddystoneUid
+		}
+		return v
+	case *EddystoneUidBeacon:
+		if v != nil {
+			return withBeaconMode(*v)
+		}
+	case EddystoneUrlBeacon:
+		if v.Mode == "" {
+			v.Mode = PlayerBeaconModeEddystoneUrl
+		}
+		return v
+	case *EddystoneUrlBeacon:
+		if v != nil {
+			return withBeaconMode(*v)
+		}
+	}
+	return b
+}
+
 // UnmarshalDeviceBeacons unmarshals a JSON array of beacons into a slice of DeviceBeacon interfaces.
 func UnmarshalDeviceBeacons(data []byte) ([]DeviceBeacon, error) {
 	var rawList []json.RawMessage
@@ -121,3 +215,103 @@ func UnmarshalDeviceBeacons(data []byte) ([]DeviceBeacon, error) {
 	}
 	return beacons, nil
 }
+
+// Eddystone UID field lengths in bytes.
+const (
+	EddystoneNamespaceIDLen = 10
+	EddystoneInstanceIDLen  = 6
+)
+
+// maxEddystoneURLLen is the maximum length of an encoded Eddystone URL after the scheme byte.
+const maxEddystoneURLLen = 17
+
+var iBeaconUUIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Validate checks that the UUID is in the 8-4-4-4-12 hexadecimal format.
+func (b IBeacon) Validate() error {
+	if !iBeaconUUIDPattern.MatchString(b.UUID) {
+		return fmt.Errorf("iBeacon %q: invalid UUID %q", b.Name, b.UUID)
+	}
+	return nil
+}
+
+// Validate checks the namespace and instance ID lengths.
+func (b EddystoneUidBeacon) Validate() error {
+	if len(b.NamespaceID) != EddystoneNamespaceIDLen {
+		return fmt.Errorf("Eddystone UID beacon %q: namespace ID must be %d bytes, got %d", b.Name, EddystoneNamespaceIDLen, len(b.NamespaceID))
+	}
+	if len(b.InstanceID) != EddystoneInstanceIDLen {
+		return fmt.Errorf("Eddystone UID beacon %q: instance ID must be %d bytes, got %d", b.Name, EddystoneInstanceIDLen, len(b.InstanceID))
+	}
+	return nil
+}
+
+// Validate checks that the URL can be broadcast in an Eddystone-URL frame.
+func (b EddystoneUrlBeacon) Validate() error {
+	if _, err := EncodeEddystoneURL(b.URL); err != nil {
+		return fmt.Errorf("Eddystone URL beacon %q: %w", b.Name, err)
+	}
+	return nil
+}
+
+// ValidateBeacons validates each beacon that has a Validate method. Unknown beacons are
+// not checked.
+func ValidateBeacons(beacons []DeviceBeacon) error {
+	var errs []error
+	for _, b := range beacons {
+		if v, ok := b.(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// eddystoneSchemes are the URL scheme prefixes of the Eddystone-URL encoding, by code.
+var eddystoneSchemes = []string{"http://www.", "https://www.", "http://", "https://"}
+
+// eddystoneExpansions are the Eddystone-URL text expansions, by code.
+var eddystoneExpansions = []string{
+	".com/", ".org/", ".edu/", ".net/", ".info/", ".biz/", ".gov/",
+	".com", ".org", ".edu", ".net", ".info", ".biz", ".gov",
+}
+
+// EncodeEddystoneURL encodes url as the URL scheme byte and encoded URL of an
+// Eddystone-URL frame. The URL must start with http:// or https://, contain only
+// printable ASCII, and encode to at most 17 bytes after the scheme.
+func EncodeEddystoneURL(url string) ([]byte, error) {
+	scheme := -1
+	for code, prefix := range eddystoneSchemes {
+		if strings.HasPrefix(url, prefix) && (scheme < 0 || len(prefix) > len(eddystoneSchemes[scheme])) {
+			scheme = code
+		}
+	}
+	if scheme < 0 {
+		return nil, fmt.Errorf("URL %q must start with http:// or https://", url)
+	}
+	out := []byte{byte(scheme)}
+	rest := url[len(eddystoneSchemes[scheme]):]
+	for len(rest) > 0 {
+		code := -1
+		for i, exp := range eddystoneExpansions {
+			if strings.HasPrefix(rest, exp) && (code < 0 || len(exp) > len(eddystoneExpansions[code])) {
+				code = i
+			}
+		}
+		if code >= 0 {
+			out = append(out, byte(code))
+			rest = rest[len(eddystoneExpansions[code]):]
+			continue
+		}
+		if c := rest[0]; c <= 0x20 || c >= 0x7f {
+			return nil, fmt.Errorf("URL %q contains a character that cannot be encoded", url)
+		}
+		out = append(out, rest[0])
+		rest = rest[1:]
+	}
+	if len(out)-1 > maxEddystoneURLLen {
+		return nil, fmt.Errorf("URL %q encodes to %d bytes, more than %d", url, len(out)-1, maxEddystoneURLLen)
+	}
+	return out, nil
+}
@@ -0,0 +1,181 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEncodeEddystoneURL(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []byte
+		wantErr bool
+	}{
+		{in: "https://goo.gl/S6zT6P", want: []byte("\x03goo.gl/S6zT6P")},
+		{in: "http://www.example.com/", want: []byte("\x00example\x00")},
+		{in: "https://www.abc.org", want: []byte("\x01abc\x08")},
+		{in: "http://a.info/x", want: []byte("\x02a\x04x")},
+		{in: "https://a.com/b.gov", want: []byte("\x03a\x00b\x0d")},
+		// Longest match wins for both the scheme and expansions.
+		{in: "https://www.x", want: []byte("\x01x")},
+		{in: "http://a.comb", want: []byte("\x02a\x07b")},
+		{in: "http://", want: []byte("\x02")},
+		{in: "http://abcdefghijklmnopq", want: []byte("\x02abcdefghijklmnopq")},
+		{in: "http://abcdefghijklmnopqr", wantErr: true},
+		{in: "https://example.co.uk/abcdefgh", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "ftp://example.com", wantErr: true},
+		{in: "HTTP://example.com", wantErr: true},
+		{in: "http://a b", wantErr: true},
+		{in: "http://café", wantErr: true},
+		{in: "http://a\x7f", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := EncodeEddystoneURL(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("EncodeEddystoneURL(%q) = %q, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("EncodeEddystoneURL(%q) error: %v", tt.in, err)
+			continue
+		}
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf("EncodeEddystoneURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestValidateBeacons(t *testing.T) {
+	tests := []struct {
+		name    string
+		beacon  DeviceBeacon
+		wantErr bool
+	}{
+		{name: "iBeacon", beacon: IBeacon{UUID: "f7826da6-4fa2-4e98-8024-bc5b71e0893e"}},
+		{name: "iBeacon upper case", beacon: IBeacon{UUID: "F7826DA6-4FA2-4E98-8024-BC5B71E0893E"}},
+		{name: "iBeacon no hyphens", beacon: IBeacon{UUID: "f7826da64fa24e988024bc5b71e0893e"}, wantErr: true},
+		{name: "iBeacon not hex", beacon: IBeacon{UUID: "g7826da6-4fa2-4e98-8024-bc5b71e0893e"}, wantErr: true},
+		{name: "iBeacon empty", beacon: IBeacon{}, wantErr: true},
+		{name: "UID", beacon: EddystoneUidBeacon{NamespaceID: make([]byte, 10), InstanceID: make([]byte, 6)}},
+		{name: "UID short namespace", beacon: EddystoneUidBeacon{NamespaceID: make([]byte, 9), InstanceID: make([]byte, 6)}, wantErr: true},
+		{name: "UID long instance", beacon: EddystoneUidBeacon{NamespaceID: make([]byte, 10), InstanceID: make([]byte, 7)}, wantErr: true},
+		{name: "URL", beacon: EddystoneUrlBeacon{URL: "https://goo.gl/S6zT6P"}},
+		{name: "URL too long", beacon: EddystoneUrlBeacon{URL: "https://example.co.uk/abcdefgh"}, wantErr: true},
+		{name: "unknown", beacon: UnknownBeacon{Mode: "AltBeacon"}},
+	}
+	for _, tt := range tests {
+		err := ValidateBeacons([]DeviceBeacon{tt.beacon})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: ValidateBeacons() error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+
+	err := ValidateBeacons([]DeviceBeacon{
+		IBeacon{Name: "a"},
+		EddystoneUrlBeacon{Name: "b", URL: "https://goo.gl/S6zT6P"},
+		EddystoneUrlBeacon{Name: "c", URL: "ftp://x"},
+	})
+	if err == nil || !strings.Contains(err.Error(), `"a"`) || !strings.Contains(err.Error(), `"c"`) || strings.Contains(err.Error(), `"b"`) {
+		t.Errorf("ValidateBeacons() error = %v, want errors for a and c only", err)
+	}
+}
+
+func TestPlayerSettingsBeaconsRoundTrip(t *testing.T) {
+	const beacons = `[` +
+		`{"name":"ib","mode":"iBeacon","major":1,"minor":2,"uuid":"f7826da6-4fa2-4e98-8024-bc5b71e0893e","power":-59},` +
+		`{"name":"uid","mode":"EddystoneUid","namespaceId":"AAECAwQFBgcICQ==","instanceId":"AAECAwQF","power":-20},` +
+		`{"name":"url","mode":"EddystoneUrl","url":"https://goo.gl/S6zT6P","power":-20},` +
+		`{"mode":"AltBeacon","name":"alt","manufacturerId":280,"power":-10}` +
+		`]`
+
+	var s PlayerSettings
+	if err := json.Unmarshal([]byte(`{"name":"Lobby","beacons":`+beacons+`}`), &s); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Beacons) != 4 {
+		t.Fatalf("decoded %d beacons, want 4", len(s.Beacons))
+	}
+	if b, ok := s.Beacons[0].(IBeacon); !ok || b.Major != 1 || b.Minor != 2 || b.Power != -59 {
+		t.Errorf("beacon 0 = %#v, want IBeacon", s.Beacons[0])
+	}
+	if b, ok := s.Beacons[1].(EddystoneUidBeacon); !ok || len(b.NamespaceID) != 10 || len(b.InstanceID) != 6 {
+		t.Errorf("beacon 1 = %#v, want EddystoneUidBeacon", s.Beacons[1])
+	}
+	if b, ok := s.Beacons[2].(EddystoneUrlBeacon); !ok || b.URL != "https://goo.gl/S6zT6P" {
+		t.Errorf("beacon 2 = %#v, want EddystoneUrlBeacon", s.Beacons[2])
+	}
+	if b, ok := s.Beacons[3].(UnknownBeacon); !ok || b.GetMode() != "AltBeacon" {
+		t.Errorf("beacon 3 = %#v, want UnknownBeacon", s.Beacons[3])
+	}
+	if err := ValidateBeacons(s.Beacons); err != nil {
+		t.Errorf("ValidateBeacons() error: %v", err)
+	}
+
+	out, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(out, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(fields["beacons"]); got != beacons {
+		t.Errorf("beacons round-trip =\n%s\nwant\n%s", got, beacons)
+	}
+	if got := string(fields["name"]); got != `"Lobby"` {
+		t.Errorf("name = %s, want \"Lobby\"", got)
+	}
+
+	// Without beacons the field is omitted.
+	out, err = json.Marshal(PlayerSettings{Name: "Lobby"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(out, []byte(`"beacons"`)) {
+		t.Errorf("Marshal() = %s, want no beacons field", out)
+	}
+}
+
+func TestDeviceBeaconWrapperMarshalSetsMode(t *testing.T) {
+	tests := []struct {
+		beacon DeviceBeacon
+		want   string
+	}{
+		{beacon: IBeacon{Name: "a"}, want: `{"name":"a","mode":"iBeacon","major":0,"minor":0,"uuid":"","power":0}`},
+		{beacon: &IBeacon{Name: "a"}, want: `{"name":"a","mode":"iBeacon","major":0,"minor":0,"uuid":"","power":0}`},
+		{beacon: EddystoneUidBeacon{Name: "b"}, want: `{"name":"b","mode":"EddystoneUid","namespaceId":null,"instanceId":null,"power":0}`},
+		{beacon: &EddystoneUrlBeacon{Name: "c"}, want: `{"name":"c","mode":"EddystoneUrl","url":"","power":0}`},
+		{beacon: UnknownBeacon{Mode: "AltBeacon"}, want: `{"mode":"AltBeacon"}`},
+	}
+	for _, tt := range tests {
+		got, err := json.Marshal(DeviceBeaconWrapper{tt.beacon})
+		if err != nil {
+			t.Errorf("Marshal(%#v) error: %v", tt.beacon, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("Marshal(%#v) = %s, want %s", tt.beacon, got, tt.want)
+		}
+	}
+}
+
+func TestUnmarshalDeviceBeaconsStrict(t *testing.T) {
+	defer SetEnumPolicy(CurrentEnumPolicy())
+	SetEnumPolicy(EnumStrict)
+
+	beacons, err := UnmarshalDeviceBeacons([]byte(`[{"mode":"iBeacon","name":"a"}]`))
+	if err != nil || len(beacons) != 1 {
+		t.Fatalf("UnmarshalDeviceBeacons() = %v, %v", beacons, err)
+	}
+	_, err = UnmarshalDeviceBeacons([]byte(`[{"mode":"AltBeacon"}]`))
+	var unknown *UnknownEnumError
+	if !errors.As(err, &unknown) || unknown.Value != "AltBeacon" {
+		t.Errorf("UnmarshalDeviceBeacons() error = %v, want *UnknownEnumError for AltBeacon", err)
+	}
+}
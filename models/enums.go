@@ -0,0 +1,94 @@
+// Package models contains shared data structures for the BSN.Cloud API client.
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// EnumPolicy controls how enum types decode values this package does not define, such as
+// a player model released after this version of the library. Under either policy, defined
+// values are matched ignoring case, as by the Parse functions, and decode to the constant.
+type EnumPolicy int32
+
+const (
+	// EnumLenient keeps unrecognised values as they are. IsKnown reports false for them,
+	// so callers can flag them. This is the default.
+	EnumLenient EnumPolicy = iota
+	// EnumStrict makes decoding fail with an *UnknownEnumError for unrecognised values.
+	EnumStrict
+)
+
+var enumPolicy atomic.Int32
+
+// SetEnumPolicy sets the policy used when decoding enums from JSON. It applies to the
+// whole process and is safe to call concurrently with decoding.
+func SetEnumPolicy(p EnumPolicy) {
+	enumPolicy.Store(int32(p))
+}
+
+// CurrentEnumPolicy returns the policy set with SetEnumPolicy.
+func CurrentEnumPolicy() EnumPolicy {
+	return EnumPolicy(enumPolicy.Load())
+}
+
+// UnknownEnumError reports a value that is not defined for an enum type.
+type UnknownEnumError struct {
+	Type  string // Enum type name, e.g. "PlayerModel"
+	Value string // Value received
+}
+
+func (e *UnknownEnumError) Error() string {
+	return fmt.Sprintf("unknown %s %q", e.Type, e.Value)
+}
+
+// isKnownEnum reports whether v is one of values.
+func isKnownEnum[T ~string](v T, values []T) bool {
+	return slices.Contains(values, v)
+}
+
+// parseEnum returns the value of values matching s, ignoring case. Otherwise it returns
+// T(s) and an *UnknownEnumError.
+func parseEnum[T ~string](name, s string, values []T) (T, error) {
+	for _, v := range values {
+		if strings.EqualFold(string(v), s) {
+			return v, nil
+		}
+	}
+	return T(s), &UnknownEnumError{Type: name, Value: s}
+}
+
+// checkEnum applies the current EnumPolicy to a decoded value. Empty values, values in
+// values and the type's Unknown constant are always accepted, ignoring case, and are
+// returned as the defined constant.
+func checkEnum[T ~string](name string, v T, values []T, unknown T) (T, error) {
+	if v == "" {
+		return v, nil
+	}
+	if known, err := parseEnum(name, string(v), values); err == nil {
+		return known, nil
+	}
+	if strings.EqualFold(string(v), string(unknown)) {
+		return unknown, nil
+	}
+	if CurrentEnumPolicy() == EnumStrict {
+		return v, &UnknownEnumError{Type: name, Value: string(v)}
+	}
+	return v, nil
+}
+
+// unmarshalEnum decodes a JSON string into an enum, applying the current EnumPolicy.
+// JSON null leaves the value empty.
+func unmarshalEnum[T ~string](data []byte, name string, values []T, unknown T) (T, error) {
+	var s *string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", err
+	}
+	if s == nil {
+		return "", nil
+	}
+	return checkEnum(name, T(*s), values, unknown)
+}
@@ -0,0 +1,183 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// withEnumPolicy sets p for the rest of the test and restores the previous policy after.
+func withEnumPolicy(t *testing.T, p EnumPolicy) {
+	prev := CurrentEnumPolicy()
+	SetEnumPolicy(p)
+	t.Cleanup(func() { SetEnumPolicy(prev) })
+}
+
+func TestParseEnum(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    PlayerModel
+		wantErr bool
+	}{
+		{in: "XT1144", want: PlayerModelXT1144},
+		{in: "xt1144", want: PlayerModelXT1144},
+		{in: "Xt1144", want: PlayerModelXT1144},
+		{in: "XZ9999", want: "XZ9999", wantErr: true},
+		{in: "Unknown", want: "Unknown", wantErr: true},
+		{in: "", want: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePlayerModel(tt.in)
+		if got != tt.want {
+			t.Errorf("ParsePlayerModel(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+		var unknown *UnknownEnumError
+		if tt.wantErr != errors.As(err, &unknown) {
+			t.Errorf("ParsePlayerModel(%q) error = %v, want *UnknownEnumError: %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr && (unknown.Type != "PlayerModel" || unknown.Value != tt.in) {
+			t.Errorf("ParsePlayerModel(%q) error = %+v", tt.in, unknown)
+		}
+	}
+
+	if got, err := ParseAccessMode("write"); got != AccessModeWrite || err != nil {
+		t.Errorf("ParseAccessMode(%q) = %q, %v; want %q", "write", got, err, AccessModeWrite)
+	}
+	if got, err := ParseNetworkConfigurationProtocol("dhcpV4"); got != NetworkConfigurationProtocolDHCPv4 || err != nil {
+		t.Errorf("ParseNetworkConfigurationProtocol(%q) = %q, %v; want %q", "dhcpV4", got, err, NetworkConfigurationProtocolDHCPv4)
+	}
+}
+
+func TestEnumIsKnown(t *testing.T) {
+	tests := []struct {
+		v    PlayerFamily
+		want bool
+	}{
+		{v: PlayerFamilyTiger, want: true},
+		{v: PlayerFamilyUnknown, want: false},
+		{v: "", want: false},
+		{v: "tiger", want: false},
+		{v: "Lynx", want: false},
+	}
+	for _, tt := range tests {
+		if got := tt.v.IsKnown(); got != tt.want {
+			t.Errorf("PlayerFamily(%q).IsKnown() = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+	for _, v := range PlayerFamilyValues() {
+		if !v.IsKnown() {
+			t.Errorf("PlayerFamily(%q).IsKnown() = false for a defined value", v)
+		}
+	}
+
+	values := PlayerModelValues()
+	values[0] = "changed"
+	if slices.Contains(PlayerModelValues(), "changed") {
+		t.Error("PlayerModelValues() returned the package's slice, want a copy")
+	}
+}
+
+func TestUnmarshalEnum(t *testing.T) {
+	tests := []struct {
+		in        string
+		want      PlayerModel
+		strictErr bool
+	}{
+		{in: `"XT1144"`, want: PlayerModelXT1144},
+		{in: `"xt1144"`, want: PlayerModelXT1144},
+		{in: `"Unknown"`, want: PlayerModelUnknown},
+		{in: `"unknown"`, want: PlayerModelUnknown},
+		{in: `""`, want: ""},
+		{in: `null`, want: ""},
+		{in: `"XZ9999"`, want: "XZ9999", strictErr: true},
+	}
+	for _, policy := range []EnumPolicy{EnumLenient, EnumStrict} {
+		withEnumPolicy(t, policy)
+		for _, tt := range tests {
+			var got PlayerModel
+			err := json.Unmarshal([]byte(tt.in), &got)
+			if policy == EnumStrict && tt.strictErr {
+				var unknown *UnknownEnumError
+				if !errors.As(err, &unknown) {
+					t.Errorf("policy %d: Unmarshal(%s) error = %v, want *UnknownEnumError", policy, tt.in, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("policy %d: Unmarshal(%s) error: %v", policy, tt.in, err)
+				continue
+			}
+			if got != tt.want {
+				t.Errorf("policy %d: Unmarshal(%s) = %q, want %q", policy, tt.in, got, tt.want)
+			}
+		}
+	}
+
+	var n struct{ Model PlayerModel }
+	if err := json.Unmarshal([]byte(`{"Model":5}`), &n); err == nil {
+		t.Errorf("Unmarshal of a number = %q, want error", n.Model)
+	}
+}
+
+func TestUnmarshalEnumCSV(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []AccessMode
+	}{
+		{in: `{"access":"Read, write"}`, want: []AccessMode{AccessModeRead, AccessModeWrite}},
+		{in: `{"access":["READ"]}`, want: []AccessMode{AccessModeRead}},
+		{in: `{"access":""}`, want: nil},
+	}
+	withEnumPolicy(t, EnumStrict)
+	for _, tt := range tests {
+		var s StorageStatus
+		if err := json.Unmarshal([]byte(tt.in), &s); err != nil {
+			t.Errorf("Unmarshal(%s) error: %v", tt.in, err)
+			continue
+		}
+		if !slices.Equal(s.Access, tt.want) {
+			t.Errorf("Unmarshal(%s).Access = %q, want %q", tt.in, s.Access, tt.want)
+		}
+	}
+
+	var n NetworkInterfaceStatus
+	err := json.Unmarshal([]byte(`{"proto":"dhcpv4,Carrier"}`), &n)
+	var unknown *UnknownEnumError
+	if !errors.As(err, &unknown) || unknown.Value != "Carrier" {
+		t.Errorf("strict Unmarshal of unknown proto error = %v, want *UnknownEnumError for %q", err, "Carrier")
+	}
+}
+
+func TestUnmarshalPlayerStrict(t *testing.T) {
+	const known = `{"id":1,"serial":"X1","model":"xt1144","family":"Pantera"}`
+	const unknown = `{"id":1,"serial":"X1","model":"XZ9999","family":"Pantera"}`
+
+	withEnumPolicy(t, EnumLenient)
+	var p Player
+	if err := json.Unmarshal([]byte(unknown), &p); err != nil {
+		t.Fatalf("lenient Unmarshal error: %v", err)
+	}
+	if p.Model != "XZ9999" || p.Model.IsKnown() {
+		t.Errorf("lenient Model = %q, IsKnown %v; want XZ9999, false", p.Model, p.Model.IsKnown())
+	}
+
+	SetEnumPolicy(EnumStrict)
+	p = Player{}
+	if err := json.Unmarshal([]byte(known), &p); err != nil {
+		t.Fatalf("strict Unmarshal of a known model error: %v", err)
+	}
+	if p.Model != PlayerModelXT1144 || p.Family != PlayerFamilyPantera {
+		t.Errorf("strict Unmarshal = %q, %q; want %q, %q", p.Model, p.Family, PlayerModelXT1144, PlayerFamilyPantera)
+	}
+
+	err := json.Unmarshal([]byte(unknown), &p)
+	var enumErr *UnknownEnumError
+	if !errors.As(err, &enumErr) {
+		t.Fatalf("strict Unmarshal error = %v, want *UnknownEnumError", err)
+	}
+	if enumErr.Type != "PlayerModel" || enumErr.Value != "XZ9999" {
+		t.Errorf("strict Unmarshal error = %+v, want PlayerModel XZ9999", enumErr)
+	}
+}
@@ -4,6 +4,7 @@ package models
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 )
 
@@ -37,6 +38,40 @@ const (
 	PlayerNetworkInterfaceTypeUnknown PlayerNetworkInterfaceType = "Unknown"
 )
 
+var playerNetworkInterfaceTypeValues = []PlayerNetworkInterfaceType{
+	PlayerNetworkInterfaceTypeEthernet,
+	PlayerNetworkInterfaceTypeWiFi,
+	PlayerNetworkInterfaceTypeVirtual,
+	PlayerNetworkInterfaceTypeOther,
+	PlayerNetworkInterfaceTypeCellular,
+}
+
+// PlayerNetworkInterfaceTypeValues returns the defined values, excluding PlayerNetworkInterfaceTypeUnknown.
+func PlayerNetworkInterfaceTypeValues() []PlayerNetworkInterfaceType {
+	return slices.Clone(playerNetworkInterfaceTypeValues)
+}
+
+// ParsePlayerNetworkInterfaceType returns the PlayerNetworkInterfaceType matching s, ignoring case.
+// For other values it returns PlayerNetworkInterfaceType(s) and an *UnknownEnumError.
+func ParsePlayerNetworkInterfaceType(s string) (PlayerNetworkInterfaceType, error) {
+	return parseEnum("PlayerNetworkInterfaceType", s, playerNetworkInterfaceTypeValues)
+}
+
+// IsKnown reports whether v is one of PlayerNetworkInterfaceTypeValues.
+func (v PlayerNetworkInterfaceType) IsKnown() bool {
+	return isKnownEnum(v, playerNetworkInterfaceTypeValues)
+}
+
+// UnmarshalJSON decodes v according to the current EnumPolicy.
+func (v *PlayerNetworkInterfaceType) UnmarshalJSON(data []byte) error {
+	parsed, err := unmarshalEnum(data, "PlayerNetworkInterfaceType", playerNetworkInterfaceTypeValues, PlayerNetworkInterfaceTypeUnknown)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
 // NetworkConfigurationProtocol is an enum for network protocols.
 type NetworkConfigurationProtocol string
 
@@ -53,6 +88,39 @@ const (
 	NetworkConfigurationProtocolUnknown NetworkConfigurationProtocol = "Unknown"
 )
 
+var networkConfigurationProtocolValues = []NetworkConfigurationProtocol{
+	NetworkConfigurationProtocolStatic,
+	NetworkConfigurationProtocolDHCPv4,
+	NetworkConfigurationProtocolDHCPv6,
+	NetworkConfigurationProtocolNDP,
+}
+
+// NetworkConfigurationProtocolValues returns the defined values, excluding NetworkConfigurationProtocolUnknown.
+func NetworkConfigurationProtocolValues() []NetworkConfigurationProtocol {
+	return slices.Clone(networkConfigurationProtocolValues)
+}
+
+// ParseNetworkConfigurationProtocol returns the NetworkConfigurationProtocol matching s, ignoring case.
+// For other values it returns NetworkConfigurationProtocol(s) and an *UnknownEnumError.
+func ParseNetworkConfigurationProtocol(s string) (NetworkConfigurationProtocol, error) {
+	return parseEnum("NetworkConfigurationProtocol", s, networkConfigurationProtocolValues)
+}
+
+// IsKnown reports whether v is one of NetworkConfigurationProtocolValues.
+func (v NetworkConfigurationProtocol) IsKnown() bool {
+	return isKnownEnum(v, networkConfigurationProtocolValues)
+}
+
+// UnmarshalJSON decodes v according to the current EnumPolicy.
+func (v *NetworkConfigurationProtocol) UnmarshalJSON(data []byte) error {
+	parsed, err := unmarshalEnum(data, "NetworkConfigurationProtocol", networkConfigurationProtocolValues, NetworkConfigurationProtocolUnknown)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
 // NetworkInterfaceStatus represents a standard network interface.
 type NetworkInterfaceStatus struct {
 	Name    string                         `json:"name"`             // Interface name
@@ -85,7 +153,11 @@ func (n *NetworkInterfaceStatus) UnmarshalJSON(data []byte) error {
 		} else {
 			parts := make([]NetworkConfigurationProtocol, 0)
 			for _, s := range splitAndTrim(v, ",") {
-				parts = append(parts, NetworkConfigurationProtocol(s))
+				proto, err := checkEnum("NetworkConfigurationProtocol", NetworkConfigurationProtocol(s), networkConfigurationProtocolValues, NetworkConfigurationProtocolUnknown)
+				if err != nil {
+					return err
+				}
+				parts = append(parts, proto)
 			}
 			n.Proto = parts
 		}
@@ -93,7 +165,11 @@ func (n *NetworkInterfaceStatus) UnmarshalJSON(data []byte) error {
 		parts := make([]NetworkConfigurationProtocol, 0, len(v))
 		for _, s := range v {
 			if str, ok := s.(string); ok {
-				parts = append(parts, NetworkConfigurationProtocol(str))
+				proto, err := checkEnum("NetworkConfigurationProtocol", NetworkConfigurationProtocol(str), networkConfigurationProtocolValues, NetworkConfigurationProtocolUnknown)
+				if err != nil {
+					return err
+				}
+				parts = append(parts, proto)
 			}
 		}
 		n.Proto = parts
@@ -0,0 +1,47 @@
+// Package models contains shared data structures for the BSN.Cloud API client.
+package models
+
+import "github.com/carrier-labs/go-bsn-cloud-client/utils"
+
+// Network represents a network in BSN.Cloud.
+type Network struct {
+	Id                          int              `json:"id"`                                    // Network ID
+	Name                        string           `json:"name"`                                  // Network name
+	CreationDate                *utils.BsnTime   `json:"creationDate,omitempty"`                // Creation date
+	LastModifiedDate            *utils.BsnTime   `json:"lastModifiedDate,omitempty"`            // Last modification date
+	IsLockedOut                 bool             `json:"isLockedOut"`                           // Whether the network is locked out
+	LockoutDate                 *utils.BsnTime   `json:"lockoutDate,omitempty"`                 // Lockout date
+	SubscriptionsActivityPeriod *TimeSpan        `json:"subscriptionsActivityPeriod,omitempty"` // Subscription activity period
+	SubscriptionsRenewalDate    *utils.BsnTime   `json:"subscriptionsRenewalDate,omitempty"`    // Next subscription renewal date
+	Settings                    *NetworkSettings `json:"settings,omitempty"`                    // Network settings
+}
+
+// NetworkListResponse is a paged list response for networks.
+type NetworkListResponse struct {
+	Items          []Network `json:"items"`                // List of networks
+	IsTruncated    bool      `json:"isTruncated"`          // Whether more pages are available
+	NextMarker     string    `json:"nextMarker,omitempty"` // Marker to request the next page
+	TotalItemCount int       `json:"totalItemCount"`       // Total number of networks
+}
+
+// NetworkSettings represents network-level settings.
+type NetworkSettings struct {
+	AutomaticSubscriptionsManagementEnabled             bool          `json:"automaticSubscriptionsManagementEnabled"`             // Whether subscriptions are assigned automatically
+	AutomaticTaggedPlayerSubscriptionsManagementEnabled bool          `json:"automaticTaggedPlayerSubscriptionsManagementEnabled"` // Whether tagged players get subscriptions automatically
+	BDeployEnabled                                      bool          `json:"bDeployEnabled"`                                      // Whether B-Deploy provisioning is enabled
+	PlayerCertificatesCheckEnabled                      bool          `json:"playerCertificatesCheckEnabled"`                      // Whether player certificates are checked
+	AllowedPlayerModels                                 []PlayerModel `json:"allowedPlayerModels,omitempty"`                       // Player models allowed on the network
+}
+
+// NetworkSubscriptionQuota represents the subscription allowance for one subscription type.
+type NetworkSubscriptionQuota struct {
+	Type      PlayerSubscriptionType `json:"type"`      // Subscription type
+	Limit     int                    `json:"limit"`     // Number of subscriptions available
+	Used      int                    `json:"used"`      // Number of subscriptions assigned to players
+	Available int                    `json:"available"` // Number of subscriptions still available
+}
+
+// NetworkSubscriptionQuotaListResponse is a list response for subscription quotas.
+type NetworkSubscriptionQuotaListResponse struct {
+	Items []NetworkSubscriptionQuota `json:"items"` // List of quotas
+}
@@ -135,9 +135,94 @@ type PlayerCellularConnectionSettings struct {
 	// TODO: Fill in fields from API docs if available
 }
 
-// Only the UnmarshalJSON method for PlayerNetworkSettings is defined here. The struct is defined in network.go.
+// UnknownInterfaceSettings holds an interface of a type this package does not model.
+// The original JSON is kept so the settings survive a read-modify-write round-trip.
+type UnknownInterfaceSettings struct {
+	Type PlayerNetworkInterfaceType // Interface type as sent by the API
+	Raw  json.RawMessage            // Original JSON object
+}
+
+// GetType returns the type of the network interface settings.
+func (u UnknownInterfaceSettings) GetType() PlayerNetworkInterfaceType { return u.Type }
+
+// MarshalJSON writes the original JSON, or just the type if there is none.
+func (u UnknownInterfaceSettings) MarshalJSON() ([]byte, error) {
+	if len(u.Raw) > 0 {
+		return u.Raw, nil
+	}
+	return json.Marshal(struct {
+		Type PlayerNetworkInterfaceType `json:"type"`
+	}{u.Type})
+}
+
+// The PlayerNetworkSettings struct is defined in network.go; its JSON methods are defined here.
+
+// MarshalJSON implements custom marshalling for PlayerNetworkSettings.
+// Interfaces without a type are given the type matching their concrete struct, so
+// settings built in code encode the same way as settings read from the API.
+func (p PlayerNetworkSettings) MarshalJSON() ([]byte, error) {
+	type Alias PlayerNetworkSettings
+	aux := struct {
+		Alias
+		Interfaces []PlayerNetworkInterfaceSettings `json:"interfaces"`
+	}{
+		Alias: Alias(p),
+	}
+	if p.Interfaces != nil {
+		aux.Interfaces = make([]PlayerNetworkInterfaceSettings, len(p.Interfaces))
+		for i, iface := range p.Interfaces {
+			aux.Interfaces[i] = withInterfaceType(iface)
+		}
+	}
+	return json.Marshal(aux)
+}
+
+// withInterfaceType returns iface with its Type set from its concrete struct if it is empty.
+func withInterfaceType(iface PlayerNetworkInterfaceSettings) PlayerNetworkInterfaceSettings {
+	switch v := iface.(type) {
+	case EthernetInterfaceSettings:
+		if v.Type == "" {
+			v.Type = PlayerNetworkInterfaceTypeEthernet
+		}
+		return v
+	case *EthernetInterfaceSettings:
+		if v != nil {
+			return withInterfaceType(*v)
+		}
+	case WiFiInterfaceSettings:
+		if v.Type == "" {
+			v.Type = PlayerNetworkInterfaceTypeWiFi
+		}
+		return v
+	case *WiFiInterfaceSettings:
+		if v != nil {
+			return withInterfaceType(*v)
+		}
+	case VirtualInterfaceSettings:
+		if v.Type == "" {
+			v.Type = PlayerNetworkInterfaceTypeVirtual
+		}
+		return v
+	case *VirtualInterfaceSettings:
+		if v != nil {
+			return withInterfaceType(*v)
+		}
+	case CellularInterfaceSettings:
+		if v.Type == "" {
+			v.Type = PlayerNetworkInterfaceTypeCellular
+		}
+		return v
+	case *CellularInterfaceSettings:
+		if v != nil {
+			return withInterfaceType(*v)
+		}
+	}
+	return iface
+}
+
 // UnmarshalJSON implements custom unmarshalling for PlayerNetworkSettings.
-// It handles the interfaces field as a sum type.
+// It handles the interfaces field as a sum type; interfaces of unrecognised types are
+// kept as UnknownInterfaceSettings, or rejected under EnumStrict.
 func (p *PlayerNetworkSettings) UnmarshalJSON(data []byte) error {
 	type Alias PlayerNetworkSettings
 	aux := &struct {
@@ -187,7 +272,10 @@ func (p *PlayerNetworkSettings) UnmarshalJSON(data []byte) error {
 			}
 			p.Interfaces = append(p.Interfaces, cell)
 		default:
-			// Unknown type, skip or handle as needed
+			p.Interfaces = append(p.Interfaces, UnknownInterfaceSettings{
+				Type: typeProbe.Type,
+				Raw:  append(json.RawMessage(nil), raw...),
+			})
 		}
 	}
 	return nil
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPlayerNetworkSettingsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		wantType []any
+	}{
+		{
+			name: "all types",
+			in: `{"hostname":"player","proxyServer":"","proxyBypass":["a"],"timeServers":["time.example.com"],"interfaces":[` +
+				`{"enabled":true,"name":"eth0","type":"Ethernet","proto":"DHCPv4","ip":[],"gateway":"","dns":[],"rateLimitDuringInitialDownloads":0,"contentDownloadEnabled":true,"textFeedsDownloadEnabled":true,"mediaFeedsDownloadEnabled":true,"healthReportingEnabled":true,"logsUploadEnabled":true},` +
+				`{"enabled":true,"name":"wlan0","type":"WiFi","ssid":"Lobby","security":{"authentication":{"mode":"WPA2","passphrase":"secret"},"encryption":{"mode":"AES"}},"proto":"DHCPv4","ip":null,"gateway":"","dns":null,"contentDownloadEnabled":false,"textFeedsDownloadEnabled":false,"mediaFeedsDownloadEnabled":false,"healthReportingEnabled":false,"logsUploadEnabled":false},` +
+				`{"enabled":false,"name":"eth0.10","type":"Virtual","parent":"eth0","vlanId":10,"proto":"StaticIPv4","ip":["10.0.0.2/24"],"gateway":"10.0.0.1","dns":["10.0.0.1"],"contentDownloadEnabled":false,"textFeedsDownloadEnabled":false,"mediaFeedsDownloadEnabled":false,"healthReportingEnabled":false,"logsUploadEnabled":false},` +
+				`{"enabled":true,"name":"ppp0","type":"Cellular","modems":[],"model":"","usbDeviceIds":[],"sims":[],"mcc":"","mnc":"","contentDownloadEnabled":true,"textFeedsDownloadEnabled":true,"mediaFeedsDownloadEnabled":true,"healthReportingEnabled":true,"logsUploadEnabled":true}` +
+				`]}`,
+			wantType: []any{EthernetInterfaceSettings{}, WiFiInterfaceSettings{}, VirtualInterfaceSettings{}, CellularInterfaceSettings{}},
+		},
+		{
+			name:     "unknown type",
+			in:       `{"hostname":"","proxyServer":"","proxyBypass":null,"timeServers":null,"interfaces":[{"type":"Bluetooth","name":"bt0","pan":{"role":"nap"}}]}`,
+			wantType: []any{UnknownInterfaceSettings{}},
+		},
+		{
+			name: "no interfaces",
+			in:   `{"hostname":"","proxyServer":"","proxyBypass":null,"timeServers":null,"interfaces":[]}`,
+		},
+	}
+	for _, tt := range tests {
+		var s PlayerNetworkSettings
+		if err := json.Unmarshal([]byte(tt.in), &s); err != nil {
+			t.Errorf("%s: Unmarshal error: %v", tt.name, err)
+			continue
+		}
+		if len(s.Interfaces) != len(tt.wantType) {
+			t.Errorf("%s: decoded %d interfaces, want %d", tt.name, len(s.Interfaces), len(tt.wantType))
+			continue
+		}
+		for i, iface := range s.Interfaces {
+			if got, want := fmt.Sprintf("%T", iface), fmt.Sprintf("%T", tt.wantType[i]); got != want {
+				t.Errorf("%s: interface %d is %s, want %s", tt.name, i, got, want)
+			}
+		}
+		out, err := json.Marshal(s)
+		if err != nil {
+			t.Errorf("%s: Marshal error: %v", tt.name, err)
+			continue
+		}
+		if string(out) != tt.in {
+			t.Errorf("%s: round-trip =\n%s\nwant\n%s", tt.name, out, tt.in)
+		}
+	}
+}
+
+func TestPlayerNetworkSettingsMarshalSetsType(t *testing.T) {
+	s := PlayerNetworkSettings{Interfaces: []PlayerNetworkInterfaceSettings{
+		EthernetInterfaceSettings{Name: "eth0"},
+		&WiFiInterfaceSettings{Name: "wlan0"},
+		VirtualInterfaceSettings{Name: "eth0.10", Type: "Custom"},
+		&CellularInterfaceSettings{Name: "ppp0"},
+		UnknownInterfaceSettings{Type: "Bluetooth"},
+	}}
+	out, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Interfaces []struct {
+			Type string `json:"type"`
+		} `json:"interfaces"`
+	}
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Ethernet", "WiFi", "Custom", "Cellular", "Bluetooth"}
+	if len(decoded.Interfaces) != len(want) {
+		t.Fatalf("encoded %d interfaces, want %d", len(decoded.Interfaces), len(want))
+	}
+	for i, iface := range decoded.Interfaces {
+		if iface.Type != want[i] {
+			t.Errorf("interface %d type = %q, want %q", i, iface.Type, want[i])
+		}
+	}
+}
+
+func TestPlayerNetworkSettingsStrict(t *testing.T) {
+	defer SetEnumPolicy(CurrentEnumPolicy())
+	SetEnumPolicy(EnumStrict)
+
+	var s PlayerNetworkSettings
+	err := json.Unmarshal([]byte(`{"interfaces":[{"type":"Bluetooth","name":"bt0"}]}`), &s)
+	var unknown *UnknownEnumError
+	if !errors.As(err, &unknown) || unknown.Value != "Bluetooth" {
+		t.Errorf("Unmarshal error = %v, want *UnknownEnumError for Bluetooth", err)
+	}
+}
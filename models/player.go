@@ -3,6 +3,7 @@ package models
 
 import (
 	"encoding/json"
+	"slices"
 
 	"github.com/carrier-labs/go-bsn-cloud-client/utils"
 )
@@ -22,9 +23,12 @@ type Player struct {
 	Permissions      []Permission       `json:"permissions"`      // Permissions
 }
 
-// PlayerListResponse is a list response for players.
+// PlayerListResponse is a paged list response for players.
 type PlayerListResponse struct {
-	Items []Player `json:"items"` // List of players
+	Items          []Player `json:"items"`                // List of players
+	IsTruncated    bool     `json:"isTruncated"`          // Whether more pages are available
+	NextMarker     string   `json:"nextMarker,omitempty"` // Marker to request the next page
+	TotalItemCount int      `json:"totalItemCount"`       // Total number of players on the network
 }
 
 // PlayerSettings represents the settings entity for a player in BSN.Cloud.
@@ -48,6 +52,48 @@ type PlayerSettings struct {
 	LastModifiedDate    *utils.BsnTime                 `json:"lastModifiedDate,omitempty"` // Last modification date
 }
 
+// UnmarshalJSON implements custom unmarshalling for PlayerSettings.
+// It decodes the beacons field as a DeviceBeacon sum type.
+func (p *PlayerSettings) UnmarshalJSON(data []byte) error {
+	type Alias PlayerSettings
+	aux := &struct {
+		*Alias
+		Beacons []DeviceBeaconWrapper `json:"beacons,omitempty"`
+	}{
+		Alias: (*Alias)(p),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	p.Beacons = nil
+	if aux.Beacons != nil {
+		p.Beacons = make([]DeviceBeacon, len(aux.Beacons))
+		for i, w := range aux.Beacons {
+			p.Beacons[i] = w.DeviceBeacon
+		}
+	}
+	return nil
+}
+
+// MarshalJSON implements custom marshalling for PlayerSettings. Beacons without a mode
+// are given the mode matching their concrete struct.
+func (p PlayerSettings) MarshalJSON() ([]byte, error) {
+	type Alias PlayerSettings
+	aux := struct {
+		Alias
+		Beacons []DeviceBeaconWrapper `json:"beacons,omitempty"`
+	}{
+		Alias: Alias(p),
+	}
+	if p.Beacons != nil {
+		aux.Beacons = make([]DeviceBeaconWrapper, len(p.Beacons))
+		for i, b := range p.Beacons {
+			aux.Beacons[i] = DeviceBeaconWrapper{b}
+		}
+	}
+	return json.Marshal(aux)
+}
+
 // DeviceSetupType is an enum for player setup types.
 type DeviceSetupType string
 
@@ -66,6 +112,40 @@ const (
 	DeviceSetupTypeUnknown DeviceSetupType = "Unknown"
 )
 
+var deviceSetupTypeValues = []DeviceSetupType{
+	DeviceSetupTypeStandalone,
+	DeviceSetupTypeBSN,
+	DeviceSetupTypeLFN,
+	DeviceSetupTypeSFN,
+	DeviceSetupTypePartnerApplication,
+}
+
+// DeviceSetupTypeValues returns the defined values, excluding DeviceSetupTypeUnknown.
+func DeviceSetupTypeValues() []DeviceSetupType {
+	return slices.Clone(deviceSetupTypeValues)
+}
+
+// ParseDeviceSetupType returns the DeviceSetupType matching s, ignoring case.
+// For other values it returns DeviceSetupType(s) and an *UnknownEnumError.
+func ParseDeviceSetupType(s string) (DeviceSetupType, error) {
+	return parseEnum("DeviceSetupType", s, deviceSetupTypeValues)
+}
+
+// IsKnown reports whether v is one of DeviceSetupTypeValues.
+func (v DeviceSetupType) IsKnown() bool {
+	return isKnownEnum(v, deviceSetupTypeValues)
+}
+
+// UnmarshalJSON decodes v according to the current EnumPolicy.
+func (v *DeviceSetupType) UnmarshalJSON(data []byte) error {
+	parsed, err := unmarshalEnum(data, "DeviceSetupType", deviceSetupTypeValues, DeviceSetupTypeUnknown)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
 // DeviceScreenSettings represents screen settings for a player.
 type DeviceScreenSettings struct {
 	IdleColor string `json:"idleColor"` // Idle color
@@ -140,6 +220,38 @@ const (
 	ScreenOrientationPortraitBottomRight ScreenOrientation = "PortraitBottomRight"
 )
 
+var screenOrientationValues = []ScreenOrientation{
+	ScreenOrientationLandscape,
+	ScreenOrientationPortraitBottomLeft,
+	ScreenOrientationPortraitBottomRight,
+}
+
+// ScreenOrientationValues returns the defined values, excluding ScreenOrientationUnknown.
+func ScreenOrientationValues() []ScreenOrientation {
+	return slices.Clone(screenOrientationValues)
+}
+
+// ParseScreenOrientation returns the ScreenOrientation matching s, ignoring case.
+// For other values it returns ScreenOrientation(s) and an *UnknownEnumError.
+func ParseScreenOrientation(s string) (ScreenOrientation, error) {
+	return parseEnum("ScreenOrientation", s, screenOrientationValues)
+}
+
+// IsKnown reports whether v is one of ScreenOrientationValues.
+func (v ScreenOrientation) IsKnown() bool {
+	return isKnownEnum(v, screenOrientationValues)
+}
+
+// UnmarshalJSON decodes v according to the current EnumPolicy.
+func (v *ScreenOrientation) UnmarshalJSON(data []byte) error {
+	parsed, err := unmarshalEnum(data, "ScreenOrientation", screenOrientationValues, ScreenOrientationUnknown)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
 // DeviceLogsSettings represents logging settings for a player.
 type DeviceLogsSettings struct {
 	EnableDiagnosticLog bool      `json:"enableDiagnosticLog"`  // Enable diagnostic log
@@ -272,6 +384,39 @@ const (
 	ScriptTypeUnknown ScriptType = "Unknown"
 )
 
+var scriptTypeValues = []ScriptType{
+	ScriptTypeSetup,
+	ScriptTypeAutorun,
+	ScriptTypeRecovery,
+	ScriptTypeCustom,
+}
+
+// ScriptTypeValues returns the defined values, excluding ScriptTypeUnknown.
+func ScriptTypeValues() []ScriptType {
+	return slices.Clone(scriptTypeValues)
+}
+
+// ParseScriptType returns the ScriptType matching s, ignoring case.
+// For other values it returns ScriptType(s) and an *UnknownEnumError.
+func ParseScriptType(s string) (ScriptType, error) {
+	return parseEnum("ScriptType", s, scriptTypeValues)
+}
+
+// IsKnown reports whether v is one of ScriptTypeValues.
+func (v ScriptType) IsKnown() bool {
+	return isKnownEnum(v, scriptTypeValues)
+}
+
+// UnmarshalJSON decodes v according to the current EnumPolicy.
+func (v *ScriptType) UnmarshalJSON(data []byte) error {
+	parsed, err := unmarshalEnum(data, "ScriptType", scriptTypeValues, ScriptTypeUnknown)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
 // ScriptPluginInfo represents a script plugin.
 type ScriptPluginInfo struct {
 	FileName string `json:"fileName"` // Plugin file name
@@ -315,13 +460,21 @@ func (s *StorageStatus) UnmarshalJSON(data []byte) error {
 			s.Access = nil
 		} else {
 			for _, part := range splitAndTrim(v, ",") {
-				s.Access = append(s.Access, AccessMode(part))
+				mode, err := checkEnum("AccessMode", AccessMode(part), accessModeValues, AccessModeUnknown)
+				if err != nil {
+					return err
+				}
+				s.Access = append(s.Access, mode)
 			}
 		}
 	case []interface{}:
 		for _, item := range v {
 			if str, ok := item.(string); ok {
-				s.Access = append(s.Access, AccessMode(str))
+				mode, err := checkEnum("AccessMode", AccessMode(str), accessModeValues, AccessModeUnknown)
+				if err != nil {
+					return err
+				}
+				s.Access = append(s.Access, mode)
 			}
 		}
 	}
@@ -346,6 +499,40 @@ const (
 	StorageInterfaceUnknown StorageInterface = "Unknown"
 )
 
+var storageInterfaceValues = []StorageInterface{
+	StorageInterfaceInternal,
+	StorageInterfaceTmp,
+	StorageInterfaceFlash,
+	StorageInterfaceSD1,
+	StorageInterfaceUSB1,
+}
+
+// StorageInterfaceValues returns the defined values, excluding StorageInterfaceUnknown.
+func StorageInterfaceValues() []StorageInterface {
+	return slices.Clone(storageInterfaceValues)
+}
+
+// ParseStorageInterface returns the StorageInterface matching s, ignoring case.
+// For other values it returns StorageInterface(s) and an *UnknownEnumError.
+func ParseStorageInterface(s string) (StorageInterface, error) {
+	return parseEnum("StorageInterface", s, storageInterfaceValues)
+}
+
+// IsKnown reports whether v is one of StorageInterfaceValues.
+func (v StorageInterface) IsKnown() bool {
+	return isKnownEnum(v, storageInterfaceValues)
+}
+
+// UnmarshalJSON decodes v according to the current EnumPolicy.
+func (v *StorageInterface) UnmarshalJSON(data []byte) error {
+	parsed, err := unmarshalEnum(data, "StorageInterface", storageInterfaceValues, StorageInterfaceUnknown)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
 // FileSystem is an enum for file systems.
 type FileSystem string
 
@@ -372,6 +559,44 @@ const (
 	FileSystemUnknown FileSystem = "Unknown"
 )
 
+var fileSystemValues = []FileSystem{
+	FileSystemExFAT,
+	FileSystemExt3,
+	FileSystemExt4,
+	FileSystemFAT12,
+	FileSystemFAT16,
+	FileSystemFAT32,
+	FileSystemHFS,
+	FileSystemHFSplus,
+	FileSystemNTFS,
+}
+
+// FileSystemValues returns the defined values, excluding FileSystemUnknown.
+func FileSystemValues() []FileSystem {
+	return slices.Clone(fileSystemValues)
+}
+
+// ParseFileSystem returns the FileSystem matching s, ignoring case.
+// For other values it returns FileSystem(s) and an *UnknownEnumError.
+func ParseFileSystem(s string) (FileSystem, error) {
+	return parseEnum("FileSystem", s, fileSystemValues)
+}
+
+// IsKnown reports whether v is one of FileSystemValues.
+func (v FileSystem) IsKnown() bool {
+	return isKnownEnum(v, fileSystemValues)
+}
+
+// UnmarshalJSON decodes v according to the current EnumPolicy.
+func (v *FileSystem) UnmarshalJSON(data []byte) error {
+	parsed, err := unmarshalEnum(data, "FileSystem", fileSystemValues, FileSystemUnknown)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
 // AccessMode is an enum for access modes.
 type AccessMode string
 
@@ -384,6 +609,37 @@ const (
 	AccessModeUnknown AccessMode = "Unknown"
 )
 
+var accessModeValues = []AccessMode{
+	AccessModeRead,
+	AccessModeWrite,
+}
+
+// AccessModeValues returns the defined values, excluding AccessModeUnknown.
+func AccessModeValues() []AccessMode {
+	return slices.Clone(accessModeValues)
+}
+
+// ParseAccessMode returns the AccessMode matching s, ignoring case.
+// For other values it returns AccessMode(s) and an *UnknownEnumError.
+func ParseAccessMode(s string) (AccessMode, error) {
+	return parseEnum("AccessMode", s, accessModeValues)
+}
+
+// IsKnown reports whether v is one of AccessModeValues.
+func (v AccessMode) IsKnown() bool {
+	return isKnownEnum(v, accessModeValues)
+}
+
+// UnmarshalJSON decodes v according to the current EnumPolicy.
+func (v *AccessMode) UnmarshalJSON(data []byte) error {
+	parsed, err := unmarshalEnum(data, "AccessMode", accessModeValues, AccessModeUnknown)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
 // StorageStats represents diagnostic storage status information. Accepts any fields from the server.
 type StorageStats map[string]any
 
@@ -401,6 +657,38 @@ const (
 	PlayerHealthStatusUnknown PlayerHealthStatus = "Unknown"
 )
 
+var playerHealthStatusValues = []PlayerHealthStatus{
+	PlayerHealthStatusNormal,
+	PlayerHealthStatusWarning,
+	PlayerHealthStatusError,
+}
+
+// PlayerHealthStatusValues returns the defined values, excluding PlayerHealthStatusUnknown.
+func PlayerHealthStatusValues() []PlayerHealthStatus {
+	return slices.Clone(playerHealthStatusValues)
+}
+
+// ParsePlayerHealthStatus returns the PlayerHealthStatus matching s, ignoring case.
+// For other values it returns PlayerHealthStatus(s) and an *UnknownEnumError.
+func ParsePlayerHealthStatus(s string) (PlayerHealthStatus, error) {
+	return parseEnum("PlayerHealthStatus", s, playerHealthStatusValues)
+}
+
+// IsKnown reports whether v is one of PlayerHealthStatusValues.
+func (v PlayerHealthStatus) IsKnown() bool {
+	return isKnownEnum(v, playerHealthStatusValues)
+}
+
+// UnmarshalJSON decodes v according to the current EnumPolicy.
+func (v *PlayerHealthStatus) UnmarshalJSON(data []byte) error {
+	parsed, err := unmarshalEnum(data, "PlayerHealthStatus", playerHealthStatusValues, PlayerHealthStatusUnknown)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
 // PlayerSynchronizationStatus is a placeholder for synchronization status.
 type PlayerSynchronizationStatus struct {
 	Settings PlayerSettingsSynchronizationStatus `json:"settings"` // Settings synchronization status
@@ -452,6 +740,37 @@ const (
 	PlayerSubscriptionTypeUnknown PlayerSubscriptionType = "Unknown"
 )
 
+var playerSubscriptionTypeValues = []PlayerSubscriptionType{
+	PlayerSubscriptionTypeContent,
+	PlayerSubscriptionTypeControl,
+}
+
+// PlayerSubscriptionTypeValues returns the defined values, excluding PlayerSubscriptionTypeUnknown.
+func PlayerSubscriptionTypeValues() []PlayerSubscriptionType {
+	return slices.Clone(playerSubscriptionTypeValues)
+}
+
+// ParsePlayerSubscriptionType returns the PlayerSubscriptionType matching s, ignoring case.
+// For other values it returns PlayerSubscriptionType(s) and an *UnknownEnumError.
+func ParsePlayerSubscriptionType(s string) (PlayerSubscriptionType, error) {
+	return parseEnum("PlayerSubscriptionType", s, playerSubscriptionTypeValues)
+}
+
+// IsKnown reports whether v is one of PlayerSubscriptionTypeValues.
+func (v PlayerSubscriptionType) IsKnown() bool {
+	return isKnownEnum(v, playerSubscriptionTypeValues)
+}
+
+// UnmarshalJSON decodes v according to the current EnumPolicy.
+func (v *PlayerSubscriptionType) UnmarshalJSON(data []byte) error {
+	parsed, err := unmarshalEnum(data, "PlayerSubscriptionType", playerSubscriptionTypeValues, PlayerSubscriptionTypeUnknown)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
 // DeviceSubscriptionStatus is an enum for subscription status.
 type DeviceSubscriptionStatus string
 
@@ -466,6 +785,38 @@ const (
 	DeviceSubscriptionStatusUnknown DeviceSubscriptionStatus = "Unknown"
 )
 
+var deviceSubscriptionStatusValues = []DeviceSubscriptionStatus{
+	DeviceSubscriptionStatusActive,
+	DeviceSubscriptionStatusSuspending,
+	DeviceSubscriptionStatusSuspended,
+}
+
+// DeviceSubscriptionStatusValues returns the defined values, excluding DeviceSubscriptionStatusUnknown.
+func DeviceSubscriptionStatusValues() []DeviceSubscriptionStatus {
+	return slices.Clone(deviceSubscriptionStatusValues)
+}
+
+// ParseDeviceSubscriptionStatus returns the DeviceSubscriptionStatus matching s, ignoring case.
+// For other values it returns DeviceSubscriptionStatus(s) and an *UnknownEnumError.
+func ParseDeviceSubscriptionStatus(s string) (DeviceSubscriptionStatus, error) {
+	return parseEnum("DeviceSubscriptionStatus", s, deviceSubscriptionStatusValues)
+}
+
+// IsKnown reports whether v is one of DeviceSubscriptionStatusValues.
+func (v DeviceSubscriptionStatus) IsKnown() bool {
+	return isKnownEnum(v, deviceSubscriptionStatusValues)
+}
+
+// UnmarshalJSON decodes v according to the current EnumPolicy.
+func (v *DeviceSubscriptionStatus) UnmarshalJSON(data []byte) error {
+	parsed, err := unmarshalEnum(data, "DeviceSubscriptionStatus", deviceSubscriptionStatusValues, DeviceSubscriptionStatusUnknown)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
 // TaggedGroupInfo represents a tagged group for a player.
 type TaggedGroupInfo struct {
 	Id   int               `json:"id"`   // Group ID
@@ -506,16 +857,33 @@ const (
 	PrincipalTypeUnknown PrincipalType = "Unknown"
 )
 
-// Network represents a network in BSN.Cloud.
-type Network struct {
-	Id   string `json:"id"`   // Network ID
-	Name string `json:"name"` // Network name
+var principalTypeValues = []PrincipalType{
+	PrincipalTypeUser,
+	PrincipalTypeRole,
+}
+
+// PrincipalTypeValues returns the defined values, excluding PrincipalTypeUnknown.
+func PrincipalTypeValues() []PrincipalType {
+	return slices.Clone(principalTypeValues)
+}
+
+// ParsePrincipalType returns the PrincipalType matching s, ignoring case.
+// For other values it returns PrincipalType(s) and an *UnknownEnumError.
+func ParsePrincipalType(s string) (PrincipalType, error) {
+	return parseEnum("PrincipalType", s, principalTypeValues)
 }
 
-// NetworkListResponse is a list response for networks.
-type NetworkListResponse struct {
-	Items []Network `json:"items"` // List of networks
+// IsKnown reports whether v is one of PrincipalTypeValues.
+func (v PrincipalType) IsKnown() bool {
+	return isKnownEnum(v, principalTypeValues)
 }
 
-// TimeSpan represents a period of time as a string.
-type TimeSpan string
+// UnmarshalJSON decodes v according to the current EnumPolicy.
+func (v *PrincipalType) UnmarshalJSON(data []byte) error {
+	parsed, err := unmarshalEnum(data, "PrincipalType", principalTypeValues, PrincipalTypeUnknown)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
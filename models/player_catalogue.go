@@ -0,0 +1,198 @@
+// Package models contains shared data structures for the BSN.Cloud API client.
+package models
+
+import (
+	"slices"
+	"strings"
+)
+
+// PlayerSeries is the product line of a player model, taken from its model name prefix.
+type PlayerSeries string
+
+const (
+	PlayerSeriesHD      PlayerSeries = "HD" // Mainstream players
+	PlayerSeriesXD      PlayerSeries = "XD" // Performance players
+	PlayerSeriesXT      PlayerSeries = "XT" // Enterprise players
+	PlayerSeriesXC      PlayerSeries = "XC" // Multi-output players
+	PlayerSeriesXS      PlayerSeries = "XS" // Series 6 XS players
+	PlayerSeriesLS      PlayerSeries = "LS" // Entry-level players
+	PlayerSeriesHS      PlayerSeries = "HS" // Slim players
+	PlayerSeriesHO      PlayerSeries = "HO" // HDMI-out PoE players
+	PlayerSeriesAU      PlayerSeries = "AU" // Audio players
+	PlayerSeries4K      PlayerSeries = "4K" // Series 2 4K players
+	PlayerSeriesLG      PlayerSeries = "LG" // Players built into LG displays
+	PlayerSeriesMD      PlayerSeries = "MD" // OEM player modules
+	PlayerSeriesUnknown PlayerSeries = "Unknown"
+)
+
+// Capability is a set of hardware features of a player model.
+type Capability uint
+
+const (
+	Capability4K         Capability = 1 << iota // 4K video output
+	CapabilityHDMIIn                            // HDMI input
+	CapabilityPoE                               // Power over Ethernet
+	CapabilityWiFiModule                        // Supports a BrightSign WiFi module
+	CapabilityCellular                          // Supports a USB cellular modem
+	CapabilityBLEBeacons                        // Can broadcast Bluetooth LE beacons
+)
+
+var capabilityNames = []struct {
+	c    Capability
+	name string
+}{
+	{Capability4K, "4K"},
+	{CapabilityHDMIIn, "HDMIIn"},
+	{CapabilityPoE, "PoE"},
+	{CapabilityWiFiModule, "WiFiModule"},
+	{CapabilityCellular, "Cellular"},
+	{CapabilityBLEBeacons, "BLEBeacons"},
+}
+
+// Has reports whether c includes every capability in other.
+func (c Capability) Has(other Capability) bool {
+	return c&other == other
+}
+
+// String returns the capability names joined by "|", e.g. "4K|PoE".
+func (c Capability) String() string {
+	var names []string
+	for _, n := range capabilityNames {
+		if c.Has(n.c) {
+			names = append(names, n.name)
+		}
+	}
+	if len(names) == 0 {
+		return "None"
+	}
+	return strings.Join(names, "|")
+}
+
+// PlayerModelInfo describes the hardware of a player model.
+type PlayerModelInfo struct {
+	Model        PlayerModel  // Player model
+	Family       PlayerFamily // Hardware family; PlayerFamilyUnknown if it has no PlayerFamily constant
+	Series       PlayerSeries // Product line
+	Generation   int          // Hardware series number, e.g. 5 for XT1145; 0 if unknown
+	Capabilities Capability   // Hardware features
+	StorageSlots int          // Removable or internal storage slots, e.g. microSD and M.2 SSD
+}
+
+// Common capability sets, for readability of the catalogue below.
+const (
+	wireless    = CapabilityWiFiModule | CapabilityBLEBeacons
+	wireless5   = wireless | CapabilityCellular
+	expandedIO  = CapabilityPoE | CapabilityHDMIIn
+	standard4K  = Capability4K | wireless
+	standard4K5 = Capability4K | wireless5
+)
+
+// playerModelCatalogue lists every known PlayerModel. Capabilities follow BrightSign's
+// published specifications for the base model; optional accessories other than the
+// WiFi module and cellular modem are not included.
+var playerModelCatalogue = map[PlayerModel]PlayerModelInfo{
+	// Series 2
+	PlayerModel4K242:  {Family: PlayerFamilyUnknown, Series: PlayerSeries4K, Generation: 2, Capabilities: Capability4K, StorageSlots: 1},
+	PlayerModel4K1042: {Family: PlayerFamilyUnknown, Series: PlayerSeries4K, Generation: 2, Capabilities: Capability4K, StorageSlots: 1},
+	PlayerModel4K1142: {Family: PlayerFamilyUnknown, Series: PlayerSeries4K, Generation: 2, Capabilities: Capability4K | CapabilityHDMIIn, StorageSlots: 1},
+
+	// Series 3
+	PlayerModelHD223:  {Family: PlayerFamilyPantera, Series: PlayerSeriesHD, Generation: 3, Capabilities: wireless, StorageSlots: 1},
+	PlayerModelHD1023: {Family: PlayerFamilyPantera, Series: PlayerSeriesHD, Generation: 3, Capabilities: wireless | CapabilityPoE, StorageSlots: 1},
+	PlayerModelHD1423: {Family: PlayerFamilyPantera, Series: PlayerSeriesHD, Generation: 3, Capabilities: wireless | CapabilityPoE, StorageSlots: 1},
+	PlayerModelHS123:  {Family: PlayerFamilyPantera, Series: PlayerSeriesHS, Generation: 3, StorageSlots: 1},
+	PlayerModelHO523:  {Family: PlayerFamilyPantera, Series: PlayerSeriesHO, Generation: 3, Capabilities: CapabilityPoE, StorageSlots: 1},
+	PlayerModelLS423:  {Family: PlayerFamilyImpala, Series: PlayerSeriesLS, Generation: 3, Capabilities: wireless, StorageSlots: 1},
+	PlayerModelXD233:  {Family: PlayerFamilyTiger, Series: PlayerSeriesXD, Generation: 3, Capabilities: standard4K, StorageSlots: 1},
+	PlayerModelXD1033: {Family: PlayerFamilyTiger, Series: PlayerSeriesXD, Generation: 3, Capabilities: standard4K | CapabilityPoE, StorageSlots: 1},
+	PlayerModelXD1133: {Family: PlayerFamilyTiger, Series: PlayerSeriesXD, Generation: 3, Capabilities: standard4K | expandedIO, StorageSlots: 1},
+	PlayerModelXT243:  {Family: PlayerFamilyTiger, Series: PlayerSeriesXT, Generation: 3, Capabilities: standard4K, StorageSlots: 2},
+	PlayerModelXT1043: {Family: PlayerFamilyTiger, Series: PlayerSeriesXT, Generation: 3, Capabilities: standard4K | CapabilityPoE, StorageSlots: 2},
+	PlayerModelXT1143: {Family: PlayerFamilyTiger, Series: PlayerSeriesXT, Generation: 3, Capabilities: standard4K | expandedIO, StorageSlots: 2},
+
+	// Series 4
+	PlayerModelHD224:  {Family: PlayerFamilyPagani, Series: PlayerSeriesHD, Generation: 4, Capabilities: standard4K, StorageSlots: 1},
+	PlayerModelHD1024: {Family: PlayerFamilyPagani, Series: PlayerSeriesHD, Generation: 4, Capabilities: standard4K | CapabilityPoE, StorageSlots: 1},
+	PlayerModelHS124:  {Family: PlayerFamilyPagani, Series: PlayerSeriesHS, Generation: 4, Capabilities: Capability4K, StorageSlots: 1},
+	PlayerModelHS144:  {Family: PlayerFamilyPagani, Series: PlayerSeriesHS, Generation: 4, Capabilities: Capability4K, StorageSlots: 1},
+	PlayerModelLS424:  {Family: PlayerFamilySebring, Series: PlayerSeriesLS, Generation: 4, Capabilities: wireless, StorageSlots: 1},
+	PlayerModelXD234:  {Family: PlayerFamilyMalibu, Series: PlayerSeriesXD, Generation: 4, Capabilities: standard4K, StorageSlots: 1},
+	PlayerModelXD1034: {Family: PlayerFamilyMalibu, Series: PlayerSeriesXD, Generation: 4, Capabilities: standard4K | CapabilityPoE, StorageSlots: 1},
+	PlayerModelXT244:  {Family: PlayerFamilyMalibu, Series: PlayerSeriesXT, Generation: 4, Capabilities: standard4K, StorageSlots: 2},
+	PlayerModelXT1144: {Family: PlayerFamilyMalibu, Series: PlayerSeriesXT, Generation: 4, Capabilities: standard4K | expandedIO, StorageSlots: 2},
+
+	// Series 5
+	PlayerModelHD225:  {Family: PlayerFamilyCobra, Series: PlayerSeriesHD, Generation: 5, Capabilities: standard4K5, StorageSlots: 1},
+	PlayerModelHD1025: {Family: PlayerFamilyCobra, Series: PlayerSeriesHD, Generation: 5, Capabilities: standard4K5 | CapabilityPoE, StorageSlots: 1},
+	PlayerModelLS425:  {Family: PlayerFamilyCobra, Series: PlayerSeriesLS, Generation: 5, Capabilities: wireless, StorageSlots: 1},
+	PlayerModelLS445:  {Family: PlayerFamilyCobra, Series: PlayerSeriesLS, Generation: 5, Capabilities: standard4K, StorageSlots: 1},
+	PlayerModelHS125:  {Family: PlayerFamilyCobra, Series: PlayerSeriesHS, Generation: 5, Capabilities: Capability4K, StorageSlots: 1},
+	PlayerModelHS145:  {Family: PlayerFamilyCobra, Series: PlayerSeriesHS, Generation: 5, Capabilities: Capability4K, StorageSlots: 1},
+	PlayerModelAU325:  {Family: PlayerFamilyCobra, Series: PlayerSeriesAU, Generation: 5, StorageSlots: 1},
+	PlayerModelAU335:  {Family: PlayerFamilyCobra, Series: PlayerSeriesAU, Generation: 5, Capabilities: CapabilityPoE, StorageSlots: 1},
+	PlayerModelXD235:  {Family: PlayerFamilyRaptor, Series: PlayerSeriesXD, Generation: 5, Capabilities: standard4K5, StorageSlots: 1},
+	PlayerModelXD1035: {Family: PlayerFamilyRaptor, Series: PlayerSeriesXD, Generation: 5, Capabilities: standard4K5 | CapabilityPoE, StorageSlots: 1},
+	PlayerModelXT245:  {Family: PlayerFamilyRaptor, Series: PlayerSeriesXT, Generation: 5, Capabilities: standard4K5, StorageSlots: 2},
+	PlayerModelXT1145: {Family: PlayerFamilyRaptor, Series: PlayerSeriesXT, Generation: 5, Capabilities: standard4K5 | expandedIO, StorageSlots: 2},
+	PlayerModelXT2145: {Family: PlayerFamilyRaptor, Series: PlayerSeriesXT, Generation: 5, Capabilities: standard4K5 | expandedIO, StorageSlots: 2},
+	PlayerModelXC2055: {Family: PlayerFamilyRaptor, Series: PlayerSeriesXC, Generation: 5, Capabilities: Capability4K, StorageSlots: 2},
+	PlayerModelXC4055: {Family: PlayerFamilyRaptor, Series: PlayerSeriesXC, Generation: 5, Capabilities: Capability4K, StorageSlots: 2},
+	PlayerModelLGUV5N: {Family: PlayerFamilyUnknown, Series: PlayerSeriesLG, Generation: 5},
+	PlayerModelMD435:  {Family: PlayerFamilyUnknown, Series: PlayerSeriesMD, Generation: 5},
+
+	// Series 6
+	PlayerModelHD226:  {Family: PlayerFamilyUnknown, Series: PlayerSeriesHD, Generation: 6, Capabilities: standard4K5, StorageSlots: 1},
+	PlayerModelHD1026: {Family: PlayerFamilyUnknown, Series: PlayerSeriesHD, Generation: 6, Capabilities: standard4K5 | CapabilityPoE, StorageSlots: 1},
+	PlayerModelXD236:  {Family: PlayerFamilyUnknown, Series: PlayerSeriesXD, Generation: 6, Capabilities: standard4K5, StorageSlots: 1},
+	PlayerModelXD1036: {Family: PlayerFamilyUnknown, Series: PlayerSeriesXD, Generation: 6, Capabilities: standard4K5 | CapabilityPoE, StorageSlots: 1},
+	PlayerModelXS156:  {Family: PlayerFamilyUnknown, Series: PlayerSeriesXS, Generation: 6, Capabilities: Capability4K, StorageSlots: 1},
+}
+
+// Info returns the catalogue entry for m. The boolean is false for models not in the
+// catalogue, such as models released after this version of the library.
+func (m PlayerModel) Info() (PlayerModelInfo, bool) {
+	info, ok := playerModelCatalogue[m]
+	if !ok {
+		return PlayerModelInfo{Model: m, Family: PlayerFamilyUnknown, Series: PlayerSeriesUnknown}, false
+	}
+	info.Model = m
+	return info, true
+}
+
+// Family returns the hardware family of m, or PlayerFamilyUnknown.
+func (m PlayerModel) Family() PlayerFamily {
+	info, _ := m.Info()
+	return info.Family
+}
+
+// Supports reports whether m has every capability in c. Models not in the catalogue
+// support nothing.
+func (m PlayerModel) Supports(c Capability) bool {
+	info, ok := m.Info()
+	return ok && info.Capabilities.Has(c)
+}
+
+// PlayerModelsWith returns the catalogued models having every capability in c, in
+// PlayerModelValues order. The result can be used in a model filter, e.g.
+// query.In(query.FieldModel, models.PlayerModelsWith(models.Capability4K)).
+func PlayerModelsWith(c Capability) []PlayerModel {
+	var out []PlayerModel
+	for _, m := range playerModelValues {
+		if m.Supports(c) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// Supports reports whether the player's model has every capability in c.
+func (p Player) Supports(c Capability) bool {
+	return p.Model.Supports(c)
+}
+
+// FilterPlayersByCapability returns the players whose model has every capability in c.
+func FilterPlayersByCapability(players []Player, c Capability) []Player {
+	return slices.DeleteFunc(slices.Clone(players), func(p Player) bool {
+		return !p.Supports(c)
+	})
+}
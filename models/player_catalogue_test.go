@@ -0,0 +1,86 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPlayerModelCatalogue(t *testing.T) {
+	for _, m := range PlayerModelValues() {
+		info, ok := m.Info()
+		if !ok {
+			t.Errorf("%s.Info() not ok, want every defined model catalogued", m)
+			continue
+		}
+		if info.Model != m {
+			t.Errorf("%s.Info().Model = %q", m, info.Model)
+		}
+		if info.Series == "" || info.Series == PlayerSeriesUnknown {
+			t.Errorf("%s.Info().Series = %q, want a product line", m, info.Series)
+		} else if !strings.HasPrefix(string(m), string(info.Series)) {
+			t.Errorf("%s.Info().Series = %q, want the model name prefix", m, info.Series)
+		}
+		if info.Generation <= 0 {
+			t.Errorf("%s.Info().Generation = %d, want a series number", m, info.Generation)
+		}
+		if info.Family != PlayerFamilyUnknown && !info.Family.IsKnown() {
+			t.Errorf("%s.Info().Family = %q, want a defined PlayerFamily", m, info.Family)
+		}
+	}
+	if n, want := len(playerModelCatalogue), len(playerModelValues); n != want {
+		t.Errorf("catalogue has %d models, want %d", n, want)
+	}
+
+	info, ok := PlayerModel("XZ9999").Info()
+	if ok || info.Model != "XZ9999" || info.Series != PlayerSeriesUnknown || info.Family != PlayerFamilyUnknown {
+		t.Errorf("Info() of an unknown model = %+v, %v", info, ok)
+	}
+	if PlayerModel("XZ9999").Supports(0) {
+		t.Error("unknown model Supports(0) = true, want false")
+	}
+}
+
+func TestPlayerModelsWith(t *testing.T) {
+	all := PlayerModelsWith(0)
+	if !slices.Equal(all, PlayerModelValues()) {
+		t.Errorf("PlayerModelsWith(0) = %v, want every model", all)
+	}
+	for _, c := range []Capability{Capability4K, CapabilityPoE | CapabilityHDMIIn} {
+		got := PlayerModelsWith(c)
+		if len(got) == 0 {
+			t.Errorf("PlayerModelsWith(%v) is empty", c)
+		}
+		for _, m := range got {
+			if !m.Supports(c) {
+				t.Errorf("PlayerModelsWith(%v) includes %s, which does not support it", c, m)
+			}
+		}
+	}
+
+	players := []Player{{Model: PlayerModelXT1144}, {Model: PlayerModelLS423}, {Model: "XZ9999"}}
+	got := FilterPlayersByCapability(players, CapabilityPoE)
+	if len(got) != 1 || got[0].Model != PlayerModelXT1144 {
+		t.Errorf("FilterPlayersByCapability(PoE) = %v, want only the XT1144", got)
+	}
+	if len(players) != 3 || players[2].Model != "XZ9999" {
+		t.Errorf("FilterPlayersByCapability modified its input: %v", players)
+	}
+}
+
+func TestCapabilityString(t *testing.T) {
+	tests := []struct {
+		c    Capability
+		want string
+	}{
+		{c: 0, want: "None"},
+		{c: Capability4K, want: "4K"},
+		{c: CapabilityPoE | Capability4K, want: "4K|PoE"},
+		{c: CapabilityWiFiModule | CapabilityBLEBeacons, want: "WiFiModule|BLEBeacons"},
+	}
+	for _, tt := range tests {
+		if got := tt.c.String(); got != tt.want {
+			t.Errorf("Capability(%d).String() = %q, want %q", tt.c, got, tt.want)
+		}
+	}
+}
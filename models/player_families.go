@@ -1,6 +1,8 @@
 // Package models contains shared data structures for the BSN.Cloud API client.
 package models
 
+import "slices"
+
 // PlayerFamily is an enumeration of all supported player families in BSN.Cloud.
 type PlayerFamily string
 
@@ -24,3 +26,40 @@ const (
 	// PlayerFamilyUnknown represents an unknown family.
 	PlayerFamilyUnknown PlayerFamily = "Unknown"
 )
+
+var playerFamilyValues = []PlayerFamily{
+	PlayerFamilyTiger,
+	PlayerFamilyPantera,
+	PlayerFamilyImpala,
+	PlayerFamilyMalibu,
+	PlayerFamilyPagani,
+	PlayerFamilySebring,
+	PlayerFamilyRaptor,
+	PlayerFamilyCobra,
+}
+
+// PlayerFamilyValues returns the defined values, excluding PlayerFamilyUnknown.
+func PlayerFamilyValues() []PlayerFamily {
+	return slices.Clone(playerFamilyValues)
+}
+
+// ParsePlayerFamily returns the PlayerFamily matching s, ignoring case.
+// For other values it returns PlayerFamily(s) and an *UnknownEnumError.
+func ParsePlayerFamily(s string) (PlayerFamily, error) {
+	return parseEnum("PlayerFamily", s, playerFamilyValues)
+}
+
+// IsKnown reports whether v is one of PlayerFamilyValues.
+func (v PlayerFamily) IsKnown() bool {
+	return isKnownEnum(v, playerFamilyValues)
+}
+
+// UnmarshalJSON decodes v according to the current EnumPolicy.
+func (v *PlayerFamily) UnmarshalJSON(data []byte) error {
+	parsed, err := unmarshalEnum(data, "PlayerFamily", playerFamilyValues, PlayerFamilyUnknown)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
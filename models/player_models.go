@@ -1,6 +1,8 @@
 // Package models contains shared data structures for the BSN.Cloud API client.
 package models
 
+import "slices"
+
 // PlayerModel is an enumeration of all supported player models in BSN.Cloud.
 type PlayerModel string
 
@@ -100,3 +102,78 @@ const (
 	// PlayerModelUnknown represents an unknown model.
 	PlayerModelUnknown PlayerModel = "Unknown"
 )
+
+var playerModelValues = []PlayerModel{
+	PlayerModelHD223,
+	PlayerModelHD1023,
+	PlayerModelHD1423,
+	PlayerModelLS423,
+	PlayerModelXD233,
+	PlayerModelXD1033,
+	PlayerModelXD1133,
+	PlayerModelXT243,
+	PlayerModelXT1043,
+	PlayerModelXT1143,
+	PlayerModel4K242,
+	PlayerModel4K1042,
+	PlayerModel4K1142,
+	PlayerModelHS123,
+	PlayerModelHO523,
+	PlayerModelXD234,
+	PlayerModelXD1034,
+	PlayerModelXT244,
+	PlayerModelXT1144,
+	PlayerModelHS124,
+	PlayerModelHS144,
+	PlayerModelLS424,
+	PlayerModelHD224,
+	PlayerModelHD1024,
+	PlayerModelAU325,
+	PlayerModelAU335,
+	PlayerModelXC2055,
+	PlayerModelXC4055,
+	PlayerModelXD235,
+	PlayerModelXD1035,
+	PlayerModelLS425,
+	PlayerModelLS445,
+	PlayerModelHS125,
+	PlayerModelHS145,
+	PlayerModelHD225,
+	PlayerModelHD1025,
+	PlayerModelXT245,
+	PlayerModelXT1145,
+	PlayerModelXT2145,
+	PlayerModelLGUV5N,
+	PlayerModelMD435,
+	PlayerModelHD226,
+	PlayerModelHD1026,
+	PlayerModelXD236,
+	PlayerModelXD1036,
+	PlayerModelXS156,
+}
+
+// PlayerModelValues returns the defined values, excluding PlayerModelUnknown.
+func PlayerModelValues() []PlayerModel {
+	return slices.Clone(playerModelValues)
+}
+
+// ParsePlayerModel returns the PlayerModel matching s, ignoring case.
+// For other values it returns PlayerModel(s) and an *UnknownEnumError.
+func ParsePlayerModel(s string) (PlayerModel, error) {
+	return parseEnum("PlayerModel", s, playerModelValues)
+}
+
+// IsKnown reports whether v is one of PlayerModelValues.
+func (v PlayerModel) IsKnown() bool {
+	return isKnownEnum(v, playerModelValues)
+}
+
+// UnmarshalJSON decodes v according to the current EnumPolicy.
+func (v *PlayerModel) UnmarshalJSON(data []byte) error {
+	parsed, err := unmarshalEnum(data, "PlayerModel", playerModelValues, PlayerModelUnknown)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
@@ -0,0 +1,107 @@
+// Package models contains shared data structures for the BSN.Cloud API client.
+package models
+
+// PlayerSettingsPatch is a partial update of PlayerSettings. Only non-nil fields are sent,
+// at every level of nesting, so values the API masks on read (such as LWS passwords and
+// WiFi passphrases) are left untouched unless set.
+type PlayerSettingsPatch struct {
+	Name            *string                           `json:"name,omitempty"`            // Player name
+	Description     *string                           `json:"description,omitempty"`     // Player description
+	Timezone        *string                           `json:"timezone,omitempty"`        // Timezone
+	Screen          *DeviceScreenSettingsPatch        `json:"screen,omitempty"`          // Screen settings
+	Synchronization *PlayerSynchronizationSettings    `json:"synchronization,omitempty"` // Synchronization settings; each non-nil entry is sent whole
+	Network         *PlayerNetworkSettingsPatch       `json:"network,omitempty"`         // Network settings
+	Logging         *DeviceLogsSettingsPatch          `json:"logging,omitempty"`         // Logging settings
+	LWS             *LocalWebServerSettingsPatch      `json:"lws,omitempty"`             // Local web server settings
+	LDWS            *DiagnosticWebServerSettingsPatch `json:"ldws,omitempty"`            // Diagnostic web server settings
+}
+
+// IsEmpty reports whether the patch changes nothing.
+func (p PlayerSettingsPatch) IsEmpty() bool {
+	return p == PlayerSettingsPatch{}
+}
+
+// DeviceScreenSettingsPatch is a partial update of DeviceScreenSettings.
+type DeviceScreenSettingsPatch struct {
+	IdleColor *string `json:"idleColor,omitempty"` // Idle color
+	SplashUrl *string `json:"splashUrl,omitempty"` // Splash screen URL
+}
+
+// DeviceLogsSettingsPatch is a partial update of DeviceLogsSettings.
+type DeviceLogsSettingsPatch struct {
+	EnableDiagnosticLog *bool     `json:"enableDiagnosticLog,omitempty"` // Enable diagnostic log
+	EnableEventLog      *bool     `json:"enableEventLog,omitempty"`      // Enable event log
+	EnablePlaybackLog   *bool     `json:"enablePlaybackLog,omitempty"`   // Enable playback log
+	EnableStateLog      *bool     `json:"enableStateLog,omitempty"`      // Enable state log
+	EnableVariableLog   *bool     `json:"enableVariableLog,omitempty"`   // Enable variable log
+	UploadAtBoot        *bool     `json:"uploadAtBoot,omitempty"`        // Upload logs at boot
+	UploadTime          *TimeSpan `json:"uploadTime,omitempty"`          // Log upload time
+}
+
+// LocalWebServerSettingsPatch is a partial update of LocalWebServerSettings.
+type LocalWebServerSettingsPatch struct {
+	Username                  *string `json:"username,omitempty"`                  // Web server username
+	Password                  *string `json:"password,omitempty"`                  // Web server password
+	EnableUpdateNotifications *bool   `json:"enableUpdateNotifications,omitempty"` // Enable update notifications
+}
+
+// DiagnosticWebServerSettingsPatch is a partial update of DiagnosticWebServerSettings.
+type DiagnosticWebServerSettingsPatch struct {
+	Password *string `json:"password,omitempty"` // Web server password
+}
+
+// PlayerNetworkSettingsPatch is a partial update of PlayerNetworkSettings.
+type PlayerNetworkSettingsPatch struct {
+	Hostname    *string                         `json:"hostname,omitempty"`    // Hostname
+	ProxyServer *string                         `json:"proxyServer,omitempty"` // Proxy server
+	ProxyBypass *[]string                       `json:"proxyBypass,omitempty"` // Proxy bypass list
+	TimeServers *[]string                       `json:"timeServers,omitempty"` // Time servers
+	Interfaces  []NetworkInterfaceSettingsPatch `json:"interfaces,omitempty"`  // Interfaces to update, matched by Name
+}
+
+// NetworkInterfaceSettingsPatch is a partial update of one network interface, identified
+// by Name. It covers the fields of every interface type; only those relevant to the
+// interface's type should be set.
+type NetworkInterfaceSettingsPatch struct {
+	Name                                  string                        `json:"name"`                                            // Interface name
+	Type                                  PlayerNetworkInterfaceType    `json:"type,omitempty"`                                  // Interface type
+	Enabled                               *bool                         `json:"enabled,omitempty"`                               // Whether the interface is enabled
+	Proto                                 *NetworkConfigurationProtocol `json:"proto,omitempty"`                                 // Protocol
+	IP                                    *[]string                     `json:"ip,omitempty"`                                    // IP addresses
+	Gateway                               *string                       `json:"gateway,omitempty"`                               // Gateway
+	DNS                                   *[]string                     `json:"dns,omitempty"`                                   // DNS servers
+	SSID                                  *string                       `json:"ssid,omitempty"`                                  // WiFi SSID
+	Security                              *WiFiSecuritySettingsPatch    `json:"security,omitempty"`                              // WiFi security settings
+	Parent                                *string                       `json:"parent,omitempty"`                                // Parent interface, for virtual interfaces
+	VlanId                                *uint16                       `json:"vlanId,omitempty"`                                // VLAN ID, for virtual interfaces
+	RateLimitDuringInitialDownloads       *int                          `json:"rateLimitDuringInitialDownloads,omitempty"`       // Rate limit during initial downloads
+	RateLimitInsideContentDownloadWindow  *int                          `json:"rateLimitInsideContentDownloadWindow,omitempty"`  // Rate limit inside content download window
+	RateLimitOutsideContentDownloadWindow *int                          `json:"rateLimitOutsideContentDownloadWindow,omitempty"` // Rate limit outside content download window
+	ContentDownloadEnabled                *bool                         `json:"contentDownloadEnabled,omitempty"`                // Content download enabled
+	TextFeedsDownloadEnabled              *bool                         `json:"textFeedsDownloadEnabled,omitempty"`              // Text feeds download enabled
+	MediaFeedsDownloadEnabled             *bool                         `json:"mediaFeedsDownloadEnabled,omitempty"`             // Media feeds download enabled
+	HealthReportingEnabled                *bool                         `json:"healthReportingEnabled,omitempty"`                // Health reporting enabled
+	LogsUploadEnabled                     *bool                         `json:"logsUploadEnabled,omitempty"`                     // Logs upload enabled
+}
+
+// WiFiSecuritySettingsPatch is a partial update of WiFiSecuritySettings.
+type WiFiSecuritySettingsPatch struct {
+	Authentication *WiFiAuthenticationSettingsPatch `json:"authentication,omitempty"` // Authentication settings
+	Encryption     *WiFiEncryptionSettingsPatch     `json:"encryption,omitempty"`     // Encryption settings
+}
+
+// WiFiAuthenticationSettingsPatch is a partial update of WiFiAuthenticationSettings.
+type WiFiAuthenticationSettingsPatch struct {
+	Mode       *string `json:"mode,omitempty"`       // Authentication mode
+	Passphrase *string `json:"passphrase,omitempty"` // Passphrase
+}
+
+// WiFiEncryptionSettingsPatch is a partial update of WiFiEncryptionSettings.
+type WiFiEncryptionSettingsPatch struct {
+	Mode *string `json:"mode,omitempty"` // Encryption mode
+}
+
+// Ptr returns a pointer to v, for building patches: Name: models.Ptr("Lobby").
+func Ptr[T any](v T) *T {
+	return &v
+}
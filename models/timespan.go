@@ -0,0 +1,145 @@
+// Package models contains shared data structures for the BSN.Cloud API client.
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeSpan is a period of time, sent by the API in the .NET TimeSpan format
+// "[-][d.]hh:mm:ss[.fffffff]", e.g. "1.02:03:04.5000000". It converts to and from
+// time.Duration, so values can be compared and sorted directly.
+type TimeSpan time.Duration
+
+// timeSpanPattern matches the .NET constant ("c") TimeSpan format.
+var timeSpanPattern = regexp.MustCompile(`^(-)?(?:(\d+)\.)?(\d{1,2}):(\d{2}):(\d{2})(?:\.(\d{1,7}))?$`)
+
+// ParseTimeSpan parses a .NET TimeSpan string such as "00:05:00" or "3.12:00:00.1234567".
+func ParseTimeSpan(s string) (TimeSpan, error) {
+	m := timeSpanPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, fmt.Errorf("TimeSpan: could not parse %q", s)
+	}
+	var days, hours, minutes, seconds int64
+	if m[2] != "" {
+		var err error
+		if days, err = strconv.ParseInt(m[2], 10, 64); err != nil || days > math.MaxInt64/int64(24*time.Hour) {
+			return 0, fmt.Errorf("TimeSpan: %q is out of range", s)
+		}
+	}
+	hours, _ = strconv.ParseInt(m[3], 10, 64)
+	minutes, _ = strconv.ParseInt(m[4], 10, 64)
+	seconds, _ = strconv.ParseInt(m[5], 10, 64)
+	if hours > 23 || minutes > 59 || seconds > 59 {
+		return 0, fmt.Errorf("TimeSpan: could not parse %q", s)
+	}
+	var fraction int64
+	if m[6] != "" {
+		// Right-pad to 9 digits so the fraction is in nanoseconds.
+		fraction, _ = strconv.ParseInt(m[6]+strings.Repeat("0", 9-len(m[6])), 10, 64)
+	}
+
+	d := time.Duration(days)*24*time.Hour +
+		time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second +
+		time.Duration(fraction)
+	if d < 0 {
+		return 0, fmt.Errorf("TimeSpan: %q is out of range", s)
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return TimeSpan(d), nil
+}
+
+// Duration returns t as a time.Duration.
+func (t TimeSpan) Duration() time.Duration {
+	return time.Duration(t)
+}
+
+// String formats t in the .NET constant ("c") TimeSpan format: the fraction is written
+// with 7 digits (100ns ticks) and omitted when zero. Output is normalised, so other
+// spellings of the same value, such as "00:05:00.0000000" or "1:02:03", format as
+// "00:05:00" and "01:02:03".
+func (t TimeSpan) String() string {
+	d := time.Duration(t)
+	var b strings.Builder
+	// Work with a non-negative value; the minimum Duration cannot be negated.
+	u := uint64(d)
+	if d < 0 {
+		b.WriteByte('-')
+		u = -u
+	}
+	days := u / uint64(24*time.Hour)
+	u -= days * uint64(24*time.Hour)
+	hours := u / uint64(time.Hour)
+	u -= hours * uint64(time.Hour)
+	minutes := u / uint64(time.Minute)
+	u -= minutes * uint64(time.Minute)
+	seconds := u / uint64(time.Second)
+	u -= seconds * uint64(time.Second)
+	ticks := u / 100
+
+	if days > 0 {
+		fmt.Fprintf(&b, "%d.", days)
+	}
+	fmt.Fprintf(&b, "%02d:%02d:%02d", hours, minutes, seconds)
+	if ticks > 0 {
+		fmt.Fprintf(&b, ".%07d", ticks)
+	}
+	return b.String()
+}
+
+// Compare returns -1 if t is shorter than u, 0 if they are equal and +1 if t is longer,
+// for use with slices.SortFunc.
+func (t TimeSpan) Compare(u TimeSpan) int {
+	switch {
+	case t < u:
+		return -1
+	case t > u:
+		return 1
+	}
+	return 0
+}
+
+// Add returns t+u.
+func (t TimeSpan) Add(u TimeSpan) TimeSpan {
+	return t + u
+}
+
+// Sub returns t-u.
+func (t TimeSpan) Sub(u TimeSpan) TimeSpan {
+	return t - u
+}
+
+// MarshalJSON outputs t as a normalised .NET TimeSpan string; see String.
+func (t TimeSpan) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON parses a .NET TimeSpan string. JSON null and "" decode as zero, so they
+// do not round-trip: a fetched value that was empty or null is sent back as "00:00:00",
+// for example by DeviceService.UpdateDeviceSettings. PatchDeviceSettings sends only the
+// fields set in a PlayerSettingsPatch.
+func (t *TimeSpan) UnmarshalJSON(data []byte) error {
+	var s *string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("TimeSpan: %w", err)
+	}
+	if s == nil || *s == "" {
+		*t = 0
+		return nil
+	}
+	parsed, err := ParseTimeSpan(*s)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
@@ -0,0 +1,178 @@
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseTimeSpan(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "00:00:00", want: 0},
+		{in: "00:05:00", want: 5 * time.Minute},
+		{in: "1:02:03", want: time.Hour + 2*time.Minute + 3*time.Second},
+		{in: "1.02:03:04", want: 26*time.Hour + 3*time.Minute + 4*time.Second},
+		{in: "3.12:00:00.1234567", want: 84*time.Hour + 123456700*time.Nanosecond},
+		{in: "00:00:00.5", want: 500 * time.Millisecond},
+		{in: "00:00:00.0000001", want: 100 * time.Nanosecond},
+		{in: "-1.02:03:04.5000000", want: -(26*time.Hour + 3*time.Minute + 4500*time.Millisecond)},
+		{in: " 00:01:00 ", want: time.Minute},
+		{in: "106751.23:47:16.8547758", want: math.MaxInt64 / 100 * 100},
+		{in: "", wantErr: true},
+		{in: "x", wantErr: true},
+		{in: "5", wantErr: true},
+		{in: "24:00:00", wantErr: true},
+		{in: "00:60:00", wantErr: true},
+		{in: "00:00:60", wantErr: true},
+		{in: "00:00:00.12345678", wantErr: true},
+		{in: "1.2.03:04:05", wantErr: true},
+		{in: "106752.00:00:00", wantErr: true},
+		{in: "99999999999999999999.00:00:00", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTimeSpan(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseTimeSpan(%q) = %v, want error", tt.in, got.Duration())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseTimeSpan(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got.Duration() != tt.want {
+			t.Errorf("ParseTimeSpan(%q) = %v, want %v", tt.in, got.Duration(), tt.want)
+		}
+	}
+}
+
+func TestTimeSpanString(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{in: 0, want: "00:00:00"},
+		{in: 5 * time.Minute, want: "00:05:00"},
+		{in: 26*time.Hour + 3*time.Minute + 4*time.Second, want: "1.02:03:04"},
+		{in: 500 * time.Millisecond, want: "00:00:00.5000000"},
+		{in: 150 * time.Nanosecond, want: "00:00:00.0000001"},
+		{in: 50 * time.Nanosecond, want: "00:00:00"},
+		{in: -(26*time.Hour + 4500*time.Millisecond), want: "-1.02:00:04.5000000"},
+		{in: math.MaxInt64, want: "106751.23:47:16.8547758"},
+		{in: math.MinInt64, want: "-106751.23:47:16.8547758"},
+	}
+	for _, tt := range tests {
+		if got := TimeSpan(tt.in).String(); got != tt.want {
+			t.Errorf("TimeSpan(%v).String() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTimeSpanJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: `"00:05:00"`, want: `"00:05:00"`},
+		{in: `"1.02:03:04.1234567"`, want: `"1.02:03:04.1234567"`},
+		{in: `"-00:00:01"`, want: `"-00:00:01"`},
+		// Other spellings are normalised.
+		{in: `"00:05:00.0000000"`, want: `"00:05:00"`},
+		{in: `"1:02:03"`, want: `"01:02:03"`},
+		{in: `"00:00:00.5"`, want: `"00:00:00.5000000"`},
+		// Empty and null values decode as zero and are not preserved.
+		{in: `null`, want: `"00:00:00"`},
+		{in: `""`, want: `"00:00:00"`},
+	}
+	for _, tt := range tests {
+		var ts TimeSpan
+		if err := json.Unmarshal([]byte(tt.in), &ts); err != nil {
+			t.Errorf("Unmarshal(%s) error: %v", tt.in, err)
+			continue
+		}
+		got, err := json.Marshal(ts)
+		if err != nil {
+			t.Errorf("Marshal(%v) error: %v", ts, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("round-trip of %s = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{`"x"`, `5`, `"24:00:00"`} {
+		var ts TimeSpan
+		if err := json.Unmarshal([]byte(in), &ts); err == nil {
+			t.Errorf("Unmarshal(%s) = %v, want error", in, ts)
+		}
+	}
+
+	var v struct {
+		Period TimeSpan  `json:"period"`
+		Upload *TimeSpan `json:"upload,omitempty"`
+	}
+	if err := json.Unmarshal([]byte(`{"period":"00:10:00"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := json.Marshal(v)
+	if want := `{"period":"00:10:00"}`; string(got) != want {
+		t.Errorf("struct round-trip = %s, want %s", got, want)
+	}
+}
+
+func TestTimeSpanEmptyInSettings(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: `{"interval":"","countLimit":0,"quality":0,"orientation":""}`, want: `{"interval":"00:00:00","countLimit":0,"quality":0,"orientation":""}`},
+		{in: `{"interval":null,"countLimit":0,"quality":0,"orientation":""}`, want: `{"interval":"00:00:00","countLimit":0,"quality":0,"orientation":""}`},
+		{in: `{"interval":"00:15:00","countLimit":0,"quality":0,"orientation":""}`, want: `{"interval":"00:15:00","countLimit":0,"quality":0,"orientation":""}`},
+	}
+	for _, tt := range tests {
+		var s PlayerScreenshotsSettings
+		if err := json.Unmarshal([]byte(tt.in), &s); err != nil {
+			t.Errorf("Unmarshal(%s) error: %v", tt.in, err)
+			continue
+		}
+		got, err := json.Marshal(s)
+		if err != nil {
+			t.Errorf("Marshal(%+v) error: %v", s, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("round-trip of %s = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+
+	// Patches leave fields that are not set out of the request.
+	got, err := json.Marshal(PlayerSettingsPatch{Name: Ptr("Lobby")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"Lobby"}`; string(got) != want {
+		t.Errorf("Marshal(patch) = %s, want %s", got, want)
+	}
+}
+
+func TestTimeSpanCompare(t *testing.T) {
+	spans := []TimeSpan{TimeSpan(time.Hour), 0, TimeSpan(-time.Second), TimeSpan(time.Minute)}
+	slices.SortFunc(spans, TimeSpan.Compare)
+	want := []TimeSpan{TimeSpan(-time.Second), 0, TimeSpan(time.Minute), TimeSpan(time.Hour)}
+	if !slices.Equal(spans, want) {
+		t.Errorf("sorted = %v, want %v", spans, want)
+	}
+	if got := TimeSpan(time.Minute).Compare(TimeSpan(time.Minute)); got != 0 {
+		t.Errorf("Compare of equal spans = %d, want 0", got)
+	}
+	if got := TimeSpan(time.Minute).Add(TimeSpan(time.Second)).Sub(TimeSpan(time.Minute)); got != TimeSpan(time.Second) {
+		t.Errorf("Add/Sub = %v, want %v", got, TimeSpan(time.Second))
+	}
+}
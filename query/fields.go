@@ -0,0 +1,32 @@
+package query
+
+// Device fields, named after the JSON paths of models.Player.
+const (
+	FieldId               Field = "id"               // Player ID
+	FieldSerial           Field = "serial"           // Serial number
+	FieldModel            Field = "model"            // Player model
+	FieldFamily           Field = "family"           // Player family
+	FieldRegistrationDate Field = "registrationDate" // Registration date
+	FieldLastModifiedDate Field = "lastModifiedDate" // Last modification date
+
+	FieldSettingsName        Field = "settings.name"        // Player name
+	FieldSettingsDescription Field = "settings.description" // Player description
+	FieldSettingsSetupType   Field = "settings.setupType"   // Setup type
+	FieldSettingsGroupId     Field = "settings.group.id"    // Assigned group ID
+	FieldSettingsGroupName   Field = "settings.group.name"  // Assigned group name
+	FieldSettingsTimezone    Field = "settings.timezone"    // Configured timezone
+
+	FieldStatusGroupId           Field = "status.group.id"           // Reported group ID
+	FieldStatusGroupName         Field = "status.group.name"         // Reported group name
+	FieldStatusScriptType        Field = "status.script.type"        // Running script type
+	FieldStatusScriptVersion     Field = "status.script.version"     // Running script version
+	FieldStatusFirmwareVersion   Field = "status.firmware.version"   // Firmware version
+	FieldStatusNetworkExternalIp Field = "status.network.externalIp" // External IP address
+	FieldStatusUptime            Field = "status.uptime"             // Uptime
+	FieldStatusTimezone          Field = "status.timezone"           // Reported timezone
+	FieldStatusHealth            Field = "status.health"             // Health status
+	FieldStatusLastModifiedDate  Field = "status.lastModifiedDate"   // Last status change
+
+	FieldSubscriptionType   Field = "subscription.type"   // Subscription type
+	FieldSubscriptionStatus Field = "subscription.status" // Subscription status
+)
@@ -0,0 +1,350 @@
+// Package query builds BSN.Cloud filter and sort expressions for list endpoints.
+//
+// Expressions follow the BSN.Cloud REST syntax, for example:
+//
+//	[model] IS IN ('XT1144','XD1034') AND [status.health] IS 'Error'
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/carrier-labs/go-bsn-cloud-client/models"
+)
+
+// Field is a property path that can be used in filter and sort expressions.
+type Field string
+
+// String returns the field in bracketed expression form, e.g. "[status.health]".
+func (f Field) String() string {
+	return "[" + string(f) + "]"
+}
+
+// Expression is a filter expression that can be sent as the filter query parameter.
+type Expression interface {
+	// Expression returns the expression in BSN.Cloud filter syntax.
+	Expression() string
+}
+
+// Raw is a pre-built filter expression that is sent unchanged.
+type Raw string
+
+// Expression returns the raw expression.
+func (r Raw) Expression() string { return string(r) }
+
+// match is an expression that matches every item or no item. It renders as an empty
+// expression; groups resolve it, and MatchesNothing reports the no-item case so callers
+// can skip the request.
+type match bool
+
+const (
+	matchAll  match = true
+	matchNone match = false
+)
+
+// Expression returns an empty string.
+func (match) Expression() string { return "" }
+
+// MatchesNothing reports whether e can match no item, such as In with no values or an
+// And containing one. Such an expression renders as empty, which the API would treat as
+// no filter, so callers must check for it before sending a request.
+func MatchesNothing(e Expression) bool {
+	return simplify(e) == matchNone
+}
+
+// simplify resolves groups containing match expressions. It returns matchAll or
+// matchNone if the result is constant, and e otherwise. A nil e matches everything.
+func simplify(e Expression) Expression {
+	switch v := e.(type) {
+	case nil:
+		return matchAll
+	case match:
+		return v
+	case group:
+		return v.simplify()
+	}
+	return e
+}
+
+// condition compares a field against one or more values with a single operator.
+type condition struct {
+	field    Field
+	operator string
+	values   []any
+	list     bool
+}
+
+// Expression returns the condition in BSN.Cloud filter syntax.
+func (c condition) Expression() string {
+	if c.values == nil {
+		return c.field.String() + " " + c.operator
+	}
+	parts := make([]string, len(c.values))
+	for i, v := range c.values {
+		parts[i] = formatValue(v)
+	}
+	if c.list {
+		return c.field.String() + " " + c.operator + " (" + strings.Join(parts, ",") + ")"
+	}
+	return c.field.String() + " " + c.operator + " " + parts[0]
+}
+
+// Is matches when the field equals value.
+func Is(f Field, value any) Expression {
+	return condition{field: f, operator: "IS", values: []any{value}}
+}
+
+// IsNot matches when the field does not equal value.
+func IsNot(f Field, value any) Expression {
+	return condition{field: f, operator: "IS NOT", values: []any{value}}
+}
+
+// In matches when the field equals any of values. Slices passed as a single
+// argument are expanded, so In(FieldModel, models) and In(FieldModel, a, b) are equivalent.
+// With no values it matches nothing; see MatchesNothing.
+func In(f Field, values ...any) Expression {
+	values = expand(values)
+	if len(values) == 0 {
+		return matchNone
+	}
+	return condition{field: f, operator: "IS IN", values: values, list: true}
+}
+
+// NotIn matches when the field equals none of values. With no values it matches
+// everything and renders as an empty expression.
+func NotIn(f Field, values ...any) Expression {
+	values = expand(values)
+	if len(values) == 0 {
+		return matchAll
+	}
+	return condition{field: f, operator: "IS NOT IN", values: values, list: true}
+}
+
+// Contains matches when the field contains value as a substring.
+func Contains(f Field, value string) Expression {
+	return condition{field: f, operator: "CONTAINS", values: []any{value}}
+}
+
+// DoesNotContain matches when the field does not contain value as a substring.
+func DoesNotContain(f Field, value string) Expression {
+	return condition{field: f, operator: "DOES NOT CONTAIN", values: []any{value}}
+}
+
+// BeginsWith matches when the field starts with value.
+func BeginsWith(f Field, value string) Expression {
+	return condition{field: f, operator: "BEGINS WITH", values: []any{value}}
+}
+
+// EndsWith matches when the field ends with value.
+func EndsWith(f Field, value string) Expression {
+	return condition{field: f, operator: "ENDS WITH", values: []any{value}}
+}
+
+// GreaterThan matches when the field is greater than value.
+func GreaterThan(f Field, value any) Expression {
+	return condition{field: f, operator: "IS GREATER THAN", values: []any{value}}
+}
+
+// LessThan matches when the field is less than value.
+func LessThan(f Field, value any) Expression {
+	return condition{field: f, operator: "IS LESS THAN", values: []any{value}}
+}
+
+// IsNull matches when the field has no value.
+func IsNull(f Field) Expression {
+	return condition{field: f, operator: "IS NULL"}
+}
+
+// IsNotNull matches when the field has a value.
+func IsNotNull(f Field) Expression {
+	return condition{field: f, operator: "IS NOT NULL"}
+}
+
+// group joins expressions with a logical operator.
+type group struct {
+	operator string
+	exprs    []Expression
+}
+
+// simplify drops members that do not affect the result. It returns matchNone for an AND
+// with a member matching nothing or an OR whose members all match nothing, matchAll for
+// an OR with a member matching everything, an AND whose members all match everything or
+// a group with no non-nil members, and otherwise a group of the remaining members.
+func (g group) simplify() Expression {
+	absorbing, neutral := matchNone, matchAll
+	if g.operator == "OR" {
+		absorbing, neutral = matchAll, matchNone
+	}
+	exprs := make([]Expression, 0, len(g.exprs))
+	empty := true
+	for _, e := range g.exprs {
+		if e == nil {
+			continue
+		}
+		empty = false
+		switch s := simplify(e); s {
+		case absorbing:
+			return absorbing
+		case neutral:
+		default:
+			exprs = append(exprs, s)
+		}
+	}
+	if empty {
+		return matchAll
+	}
+	if len(exprs) == 0 {
+		return neutral
+	}
+	return group{operator: g.operator, exprs: exprs}
+}
+
+// Expression returns the group in BSN.Cloud filter syntax. Members other than single
+// conditions, such as nested groups and Raw expressions, are parenthesised. A group that
+// matches everything or nothing renders as an empty expression.
+func (g group) Expression() string {
+	simplified, ok := g.simplify().(group)
+	if !ok {
+		return ""
+	}
+	parts := make([]string, 0, len(simplified.exprs))
+	for _, e := range simplified.exprs {
+		s := e.Expression()
+		if s == "" {
+			continue
+		}
+		if len(simplified.exprs) > 1 && needsParens(e) {
+			s = "(" + s + ")"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " "+g.operator+" ")
+}
+
+// needsParens reports whether e must be parenthesised when joined with other members of
+// a group. Only conditions are known to bind tighter than AND and OR; Raw and other
+// expressions may contain operators of their own.
+func needsParens(e Expression) bool {
+	switch v := e.(type) {
+	case condition:
+		return false
+	case group:
+		return len(v.exprs) != 1 || needsParens(v.exprs[0])
+	}
+	return true
+}
+
+// And matches when all expressions match. Nil expressions are ignored; an And with a
+// member that matches nothing matches nothing.
+func And(exprs ...Expression) Expression {
+	return group{operator: "AND", exprs: exprs}
+}
+
+// Or matches when any expression matches. Nil expressions are ignored, as are members
+// that match nothing; an Or with only nil members does not filter.
+func Or(exprs ...Expression) Expression {
+	return group{operator: "OR", exprs: exprs}
+}
+
+// Direction is a sort direction.
+type Direction string
+
+const (
+	// Ascending sorts from lowest to highest.
+	Ascending Direction = "ASC"
+	// Descending sorts from highest to lowest.
+	Descending Direction = "DESC"
+)
+
+// Order sorts results by a single field.
+type Order struct {
+	Field     Field     // Field to sort by
+	Direction Direction // Sort direction; if empty, Ascending is used
+}
+
+// String returns the order in BSN.Cloud sort syntax, e.g. "[serial] ASC".
+func (o Order) String() string {
+	dir := o.Direction
+	if dir == "" {
+		dir = Ascending
+	}
+	return o.Field.String() + " " + string(dir)
+}
+
+// Asc sorts by f in ascending order.
+func Asc(f Field) Order { return Order{Field: f, Direction: Ascending} }
+
+// Desc sorts by f in descending order.
+func Desc(f Field) Order { return Order{Field: f, Direction: Descending} }
+
+// Sort joins orders into a single sort expression.
+func Sort(orders ...Order) string {
+	parts := make([]string, len(orders))
+	for i, o := range orders {
+		parts[i] = o.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// Quote returns s as a single-quoted string literal, doubling embedded quotes.
+func Quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// formatValue renders a Go value as a filter literal. Durations are written in the .NET
+// TimeSpan format the API uses, like models.TimeSpan.
+func formatValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return Quote(val)
+	case time.Time:
+		return Quote(val.UTC().Format("2006-01-02T15:04:05.000Z"))
+	case time.Duration:
+		return Quote(models.TimeSpan(val).String())
+	case fmt.Stringer:
+		return Quote(val.String())
+	case bool:
+		if val {
+			return "TRUE"
+		}
+		return "FALSE"
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return Quote(rv.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 64)
+	case reflect.Bool:
+		if rv.Bool() {
+			return "TRUE"
+		}
+		return "FALSE"
+	}
+	return Quote(fmt.Sprint(v))
+}
+
+// expand flattens a single slice argument into its elements.
+func expand(values []any) []any {
+	if len(values) != 1 {
+		return values
+	}
+	rv := reflect.ValueOf(values[0])
+	if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() == reflect.Uint8 {
+		return values
+	}
+	out := make([]any, rv.Len())
+	for i := range out {
+		out[i] = rv.Index(i).Interface()
+	}
+	return out
+}
@@ -0,0 +1,175 @@
+package query
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+type testString string
+
+type testBool bool
+
+type testStringer int
+
+func (s testStringer) String() string { return "it's " + string(rune('0'+s)) }
+
+type customExpr string
+
+func (e customExpr) Expression() string { return string(e) }
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: "''"},
+		{in: "XT1144", want: "'XT1144'"},
+		{in: "O'Brien", want: "'O''Brien'"},
+		{in: "''", want: "''''''"},
+		{in: "a' OR '1'='1", want: "'a'' OR ''1''=''1'"},
+		{in: `back\slash "double"`, want: `'back\slash "double"'`},
+	}
+	for _, tt := range tests {
+		if got := Quote(tt.in); got != tt.want {
+			t.Errorf("Quote(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		name string
+		in   any
+		want string
+	}{
+		{name: "nil", in: nil, want: "NULL"},
+		{name: "string", in: "Lobby", want: "'Lobby'"},
+		{name: "string with quote", in: "Bob's", want: "'Bob''s'"},
+		{name: "named string", in: testString("a'b"), want: "'a''b'"},
+		{name: "time", in: time.Date(2024, 3, 1, 12, 30, 0, 123456789, time.FixedZone("", 2*60*60)), want: "'2024-03-01T10:30:00.123Z'"},
+		{name: "stringer", in: testStringer(1), want: "'it''s 1'"},
+		{name: "true", in: true, want: "TRUE"},
+		{name: "false", in: false, want: "FALSE"},
+		{name: "named bool", in: testBool(true), want: "TRUE"},
+		{name: "int", in: 42, want: "42"},
+		{name: "negative int8", in: int8(-3), want: "-3"},
+		{name: "max uint64", in: uint64(math.MaxUint64), want: "18446744073709551615"},
+		{name: "float", in: 1.5, want: "1.5"},
+		{name: "large float", in: 1e21, want: "1000000000000000000000"},
+		{name: "duration", in: 90 * time.Minute, want: "'01:30:00'"},
+		{name: "negative duration", in: -(26*time.Hour + 500*time.Millisecond), want: "'-1.02:00:00.5000000'"},
+		{name: "other", in: struct{ A int }{1}, want: "'{1}'"},
+	}
+	for _, tt := range tests {
+		if got := formatValue(tt.in); got != tt.want {
+			t.Errorf("%s: formatValue(%#v) = %s, want %s", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExpression(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    Expression
+		want    string
+		nothing bool
+	}{
+		{name: "is", expr: Is(FieldModel, "XT1144"), want: "[model] IS 'XT1144'"},
+		{name: "is not", expr: IsNot(FieldStatusHealth, "Error"), want: "[status.health] IS NOT 'Error'"},
+		{name: "is null", expr: IsNull(FieldSettingsGroupId), want: "[settings.group.id] IS NULL"},
+		{name: "is not null", expr: IsNotNull(FieldSettingsGroupId), want: "[settings.group.id] IS NOT NULL"},
+		{name: "contains", expr: Contains(FieldSettingsName, "O'Brien"), want: "[settings.name] CONTAINS 'O''Brien'"},
+		{name: "greater than", expr: GreaterThan(FieldStatusUptime, 60), want: "[status.uptime] IS GREATER THAN 60"},
+		{name: "in values", expr: In(FieldModel, "XT1144", "XD1034"), want: "[model] IS IN ('XT1144','XD1034')"},
+		{name: "in slice", expr: In(FieldModel, []string{"XT1144", "XD1034"}), want: "[model] IS IN ('XT1144','XD1034')"},
+		{name: "in one", expr: In(FieldModel, "XT1144"), want: "[model] IS IN ('XT1144')"},
+		{name: "not in", expr: NotIn(FieldModel, []testString{"XT1144"}), want: "[model] IS NOT IN ('XT1144')"},
+		{name: "in no values", expr: In(FieldModel), want: "", nothing: true},
+		{name: "in empty slice", expr: In(FieldModel, []string{}), want: "", nothing: true},
+		{name: "not in no values", expr: NotIn(FieldModel), want: ""},
+		{name: "not in empty slice", expr: NotIn(FieldModel, []string(nil)), want: ""},
+		{
+			name: "and",
+			expr: And(Is(FieldModel, "XT1144"), Is(FieldStatusHealth, "Error")),
+			want: "[model] IS 'XT1144' AND [status.health] IS 'Error'",
+		},
+		{
+			name: "nested group",
+			expr: And(In(FieldModel, "XT1144"), Or(Is(FieldStatusHealth, "Error"), Is(FieldStatusHealth, "Warning"))),
+			want: "[model] IS IN ('XT1144') AND ([status.health] IS 'Error' OR [status.health] IS 'Warning')",
+		},
+		{
+			name: "single-member group is not parenthesised",
+			expr: And(Is(FieldModel, "XT1144"), Or(nil, Is(FieldStatusHealth, "Error"))),
+			want: "[model] IS 'XT1144' AND [status.health] IS 'Error'",
+		},
+		{name: "nil members", expr: And(nil, Is(FieldModel, "XT1144"), nil), want: "[model] IS 'XT1144'"},
+		{name: "empty and", expr: And(), want: ""},
+		{name: "empty or", expr: Or(), want: ""},
+		{name: "nil or", expr: Or(nil, nil), want: ""},
+		{name: "and with in no values", expr: And(Is(FieldModel, "XT1144"), In(FieldSerial)), want: "", nothing: true},
+		{name: "and with not in no values", expr: And(Is(FieldModel, "XT1144"), NotIn(FieldSerial)), want: "[model] IS 'XT1144'"},
+		{name: "or with in no values", expr: Or(Is(FieldModel, "XT1144"), In(FieldSerial)), want: "[model] IS 'XT1144'"},
+		{name: "or of in no values", expr: Or(In(FieldModel), In(FieldSerial)), want: "", nothing: true},
+		{name: "or with not in no values", expr: Or(Is(FieldModel, "XT1144"), NotIn(FieldSerial)), want: ""},
+		{name: "nested nothing", expr: And(Is(FieldModel, "XT1144"), Or(In(FieldSerial))), want: "", nothing: true},
+		{
+			name: "nested nothing dropped from or",
+			expr: Or(Is(FieldModel, "XT1144"), And(Is(FieldSerial, "A"), In(FieldSerial)), Is(FieldModel, "XD1034")),
+			want: "[model] IS 'XT1144' OR [model] IS 'XD1034'",
+		},
+		{name: "raw", expr: Raw("[model] IS 'XT1144'"), want: "[model] IS 'XT1144'"},
+		{
+			name: "raw in and",
+			expr: And(Raw("[a] IS 1 OR [b] IS 2"), Is(FieldSerial, "X")),
+			want: "([a] IS 1 OR [b] IS 2) AND [serial] IS 'X'",
+		},
+		{
+			name: "raw in or",
+			expr: Or(Is(FieldSerial, "X"), Raw("[a] IS 1 AND [b] IS 2")),
+			want: "[serial] IS 'X' OR ([a] IS 1 AND [b] IS 2)",
+		},
+		{name: "raw alone in group", expr: And(Raw("[a] IS 1 OR [b] IS 2")), want: "[a] IS 1 OR [b] IS 2"},
+		{
+			name: "raw in single-member nested group",
+			expr: And(Is(FieldSerial, "X"), Or(nil, Raw("[a] IS 1 OR [b] IS 2"))),
+			want: "[serial] IS 'X' AND ([a] IS 1 OR [b] IS 2)",
+		},
+		{
+			name: "custom expression",
+			expr: And(customExpr("[a] IS 1 OR [b] IS 2"), IsNull(FieldSerial)),
+			want: "([a] IS 1 OR [b] IS 2) AND [serial] IS NULL",
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.expr.Expression(); got != tt.want {
+			t.Errorf("%s: Expression() = %q, want %q", tt.name, got, tt.want)
+		}
+		if got := MatchesNothing(tt.expr); got != tt.nothing {
+			t.Errorf("%s: MatchesNothing() = %v, want %v", tt.name, got, tt.nothing)
+		}
+	}
+
+	if MatchesNothing(nil) {
+		t.Error("MatchesNothing(nil) = true, want false")
+	}
+}
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		name   string
+		orders []Order
+		want   string
+	}{
+		{name: "none", orders: nil, want: ""},
+		{name: "asc", orders: []Order{Asc(FieldSerial)}, want: "[serial] ASC"},
+		{name: "default direction", orders: []Order{{Field: FieldSerial}}, want: "[serial] ASC"},
+		{name: "several", orders: []Order{Desc(FieldLastModifiedDate), Asc(FieldSerial)}, want: "[lastModifiedDate] DESC,[serial] ASC"},
+	}
+	for _, tt := range tests {
+		if got := Sort(tt.orders...); got != tt.want {
+			t.Errorf("%s: Sort() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -4,13 +4,52 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
 
 	"github.com/carrier-labs/go-bsn-cloud-client/client"
-	"github.com/carrier-labs/go-bsn-cloud-client/debug"
 	"github.com/carrier-labs/go-bsn-cloud-client/models"
+	"github.com/carrier-labs/go-bsn-cloud-client/query"
 )
 
+// DefaultDevicePageSize is the number of devices requested per page when no page size is given.
+const DefaultDevicePageSize = 100
+
+// DeviceQuery narrows and orders the devices returned by list calls.
+// The zero value returns every device in server order.
+type DeviceQuery struct {
+	Filter   query.Expression // Optional filter expression
+	Sort     []query.Order    // Optional sort order
+	PageSize int              // Optional; if zero, DefaultDevicePageSize is used
+}
+
+// values encodes the query as URL parameters for the /Devices endpoint.
+func (q *DeviceQuery) values(marker string) url.Values {
+	params := url.Values{}
+	pageSize := DefaultDevicePageSize
+	if q != nil {
+		if q.PageSize > 0 {
+			pageSize = q.PageSize
+		}
+		if q.Filter != nil {
+			if filter := q.Filter.Expression(); filter != "" {
+				params.Set("filter", filter)
+			}
+		}
+		if len(q.Sort) > 0 {
+			params.Set("sort", query.Sort(q.Sort...))
+		}
+	}
+	params.Set("pageSize", strconv.Itoa(pageSize))
+	if marker != "" {
+		params.Set("marker", marker)
+	}
+	return params
+}
+
 type DeviceService struct {
 	Client *client.Client
 }
@@ -20,28 +59,194 @@ func NewDeviceService(c *client.Client) *DeviceService {
 	return &DeviceService{Client: c}
 }
 
-// GetDevices fetches the list of devices from BSN.Cloud using the configured network context.
-func (s *DeviceService) GetDevices(ctx context.Context) ([]models.Player, error) {
-	if err := s.Client.Authenticate(ctx); err != nil {
-		debug.Debug("DeviceService: authentication error", "error", err)
-		return nil, fmt.Errorf("authentication error: %w", err)
+// GetDevices fetches every device matching q from BSN.Cloud using the configured network
+// context, walking all pages. A nil q returns every device. If a page fails part way
+// through, the devices fetched so far are returned together with the error.
+func (s *DeviceService) GetDevices(ctx context.Context, q *DeviceQuery) ([]models.Player, error) {
+	var devices []models.Player
+	for device, err := range s.AllDevices(ctx, q) {
+		if err != nil {
+			return devices, err
+		}
+		devices = append(devices, device)
 	}
+	return devices, nil
+}
+
+// AllDevices returns an iterator over every device matching q on the network. Pages are fetched lazily
+// as the caller ranges over the sequence, so only one page is held in memory at a time.
+// On failure the iterator yields a zero Player with the error and stops.
+func (s *DeviceService) AllDevices(ctx context.Context, q *DeviceQuery) iter.Seq2[models.Player, error] {
+	return func(yield func(models.Player, error) bool) {
+		marker := ""
+		for page := 1; ; page++ {
+			if err := ctx.Err(); err != nil {
+				yield(models.Player{}, err)
+				return
+			}
+			result, err := s.GetDevicesPage(ctx, q, marker)
+			if err != nil {
+				yield(models.Player{}, fmt.Errorf("fetching devices page %d: %w", page, err))
+				return
+			}
+			for _, device := range result.Items {
+				if !yield(device, nil) {
+					return
+				}
+			}
+			if !result.IsTruncated || result.NextMarker == "" {
+				return
+			}
+			marker = result.NextMarker
+		}
+	}
+}
 
-	url := "/Devices"
-	respBody, err := s.Client.DoRequest(ctx, "GET", url, nil)
+// GetDevicesPage fetches a single page of devices matching q. Pass an empty marker for the
+// first page and the previous page's NextMarker for subsequent pages. If q's filter matches
+// nothing, an empty page is returned without calling the API.
+func (s *DeviceService) GetDevicesPage(ctx context.Context, q *DeviceQuery, marker string) (*models.PlayerListResponse, error) {
+	if q != nil && query.MatchesNothing(q.Filter) {
+		return &models.PlayerListResponse{Items: []models.Player{}}, nil
+	}
+	endpoint := "/Devices?" + q.values(marker).Encode()
+	respBody, err := s.Client.DoRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
-		debug.Debug("DeviceService: API error", "error", err)
+		s.Client.Logger().Debug("DeviceService: API error", "error", err)
 		return nil, err
 	}
-	debug.Debug("DeviceService: raw response body", "body", string(respBody))
+	s.Client.Logger().Debug("DeviceService: raw response body", "body", s.Client.RedactBody(respBody))
 
 	var result models.PlayerListResponse
 	if err := json.Unmarshal(respBody, &result); err != nil {
-		debug.Debug("DeviceService: decode error", "error", err)
+		s.Client.Logger().Error("DeviceService: decode error", "error", err)
 		return nil, fmt.Errorf("parsing devices: %w", err)
 	}
-	pretty, _ := json.MarshalIndent(result, "", "  ")
-	debug.Debug("DeviceService: API response", "data", string(pretty))
+	s.Client.Logger().Debug("DeviceService: page fetched", "count", len(result.Items), "total", result.TotalItemCount, "truncated", result.IsTruncated)
+
+	return &result, nil
+}
+
+// GetDevice fetches a single device by its numeric id. If the device does not exist a
+// *NotFoundError is returned.
+func (s *DeviceService) GetDevice(ctx context.Context, id int) (*models.Player, error) {
+	endpoint := "/Devices/" + strconv.Itoa(id) + "/"
+	respBody, err := s.Client.DoRequest(ctx, "GET", endpoint, nil)
+	if client.IsNotFound(err) {
+		return nil, &NotFoundError{Resource: "device", Key: "id " + strconv.Itoa(id), Err: err}
+	}
+	if err != nil {
+		s.Client.Logger().Debug("DeviceService: API error", "error", err)
+		return nil, err
+	}
+
+	var device models.Player
+	if err := json.Unmarshal(respBody, &device); err != nil {
+		s.Client.Logger().Error("DeviceService: decode error", "error", err)
+		return nil, fmt.Errorf("parsing device: %w", err)
+	}
+	return &device, nil
+}
+
+// GetDeviceBySerial fetches a single device by its serial number. If no device with that
+// serial exists on the network a *NotFoundError is returned.
+func (s *DeviceService) GetDeviceBySerial(ctx context.Context, serial string) (*models.Player, error) {
+	q := &DeviceQuery{Filter: query.Is(query.FieldSerial, serial), PageSize: 1}
+	result, err := s.GetDevicesPage(ctx, q, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Items) == 0 {
+		return nil, &NotFoundError{Resource: "device", Key: "serial " + serial}
+	}
+	return &result.Items[0], nil
+}
+
+// UpdateDeviceSettings replaces the settings of the device with the given id. Every field
+// of settings is sent, including values the API masks on read such as LWS and LDWS
+// passwords, and empty TimeSpan values, which are sent as "00:00:00"; prefer
+// PatchDeviceSettings when settings came from a previous fetch. Beacons are validated
+// before the request is sent.
+func (s *DeviceService) UpdateDeviceSettings(ctx context.Context, id int, settings models.PlayerSettings) error {
+	if err := models.ValidateBeacons(settings.Beacons); err != nil {
+		return fmt.Errorf("invalid beacons: %w", err)
+	}
+	return s.putDeviceSettings(ctx, id, settings)
+}
+
+// PatchDeviceSettings updates only the fields set in patch on the device with the given id.
+// An empty patch is a no-op.
+func (s *DeviceService) PatchDeviceSettings(ctx context.Context, id int, patch models.PlayerSettingsPatch) error {
+	if patch.IsEmpty() {
+		return nil
+	}
+	return s.putDeviceSettings(ctx, id, patch)
+}
+
+// putDeviceSettings sends settings to PUT /Devices/{id}/.
+func (s *DeviceService) putDeviceSettings(ctx context.Context, id int, settings any) error {
+	body := struct {
+		Id       int `json:"id"`
+		Settings any `json:"settings"`
+	}{Id: id, Settings: settings}
+	endpoint := "/Devices/" + strconv.Itoa(id) + "/"
+	_, err := s.Client.DoRequest(ctx, "PUT", endpoint, body)
+	if client.IsNotFound(err) {
+		return &NotFoundError{Resource: "device", Key: "id " + strconv.Itoa(id), Err: err}
+	}
+	if err != nil {
+		s.Client.Logger().Debug("DeviceService: API error", "error", err)
+		return err
+	}
+	return nil
+}
 
-	return result.Items, nil
+// DeleteDevice removes the device with the given id from the network. If the device does
+// not exist a *NotFoundError is returned.
+func (s *DeviceService) DeleteDevice(ctx context.Context, id int) error {
+	endpoint := "/Devices/" + strconv.Itoa(id) + "/"
+	_, err := s.Client.DoRequest(ctx, "DELETE", endpoint, nil)
+	if client.IsNotFound(err) {
+		return &NotFoundError{Resource: "device", Key: "id " + strconv.Itoa(id), Err: err}
+	}
+	if err != nil {
+		s.Client.Logger().Debug("DeviceService: API error", "error", err)
+		return err
+	}
+	return nil
+}
+
+// DeletedCountUnknown is returned by DeleteDevices when the API confirms the deletion
+// without reporting how many devices were removed.
+const DeletedCountUnknown = -1
+
+// DeleteDevices removes every device matching filter from the network and returns how many
+// were removed, or DeletedCountUnknown if the API does not report a count. A nil or empty
+// filter is rejected so a network cannot be emptied by accident; a filter that matches
+// nothing, such as query.In with no values, removes nothing.
+func (s *DeviceService) DeleteDevices(ctx context.Context, filter query.Expression) (int, error) {
+	if query.MatchesNothing(filter) {
+		return 0, nil
+	}
+	if filter == nil || filter.Expression() == "" {
+		return 0, errors.New("DeleteDevices: filter is required")
+	}
+	params := url.Values{}
+	params.Set("filter", filter.Expression())
+	endpoint := "/Devices/?" + params.Encode()
+	respBody, err := s.Client.DoRequest(ctx, "DELETE", endpoint, nil)
+	if err != nil {
+		s.Client.Logger().Debug("DeviceService: API error", "error", err)
+		return 0, err
+	}
+	if len(respBody) == 0 {
+		return DeletedCountUnknown, nil
+	}
+
+	var removed int
+	if err := json.Unmarshal(respBody, &removed); err != nil {
+		s.Client.Logger().Error("DeviceService: decode error", "error", err)
+		return 0, fmt.Errorf("parsing deleted device count: %w", err)
+	}
+	return removed, nil
 }
@@ -0,0 +1,28 @@
+// Package service provides logical groupings of BSN.Cloud API endpoints.
+package service
+
+import "errors"
+
+// NotFoundError is returned when a requested resource does not exist on the network.
+// It is distinct from transport and authentication failures.
+type NotFoundError struct {
+	Resource string // Kind of resource, e.g. "device"
+	Key      string // Identifier that was looked up, e.g. "id 42" or "serial XTC1234"
+	Err      error  // Underlying API error, if any
+}
+
+// Error implements the error interface.
+func (e *NotFoundError) Error() string {
+	return e.Resource + " " + e.Key + " not found"
+}
+
+// Unwrap returns the underlying API error.
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound reports whether err is or wraps a *NotFoundError.
+func IsNotFound(err error) bool {
+	var nf *NotFoundError
+	return errors.As(err, &nf)
+}
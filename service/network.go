@@ -0,0 +1,98 @@
+// Package service provides logical groupings of BSN.Cloud API endpoints.
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/carrier-labs/go-bsn-cloud-client/client"
+	"github.com/carrier-labs/go-bsn-cloud-client/models"
+)
+
+type NetworkService struct {
+	Client *client.Client
+}
+
+// NewNetworkService creates a new NetworkService.
+func NewNetworkService(c *client.Client) *NetworkService {
+	return &NetworkService{Client: c}
+}
+
+// ListNetworks fetches every network the client's credentials can access.
+func (s *NetworkService) ListNetworks(ctx context.Context) ([]models.Network, error) {
+	return s.Client.Networks(ctx)
+}
+
+// GetCurrentNetwork fetches the network selected for the client's session.
+func (s *NetworkService) GetCurrentNetwork(ctx context.Context) (*models.Network, error) {
+	respBody, err := s.Client.DoRequest(ctx, "GET", "/self/session/network", nil)
+	if err != nil {
+		s.Client.Logger().Debug("NetworkService: API error", "error", err)
+		return nil, err
+	}
+
+	var network models.Network
+	if err := json.Unmarshal(respBody, &network); err != nil {
+		s.Client.Logger().Error("NetworkService: decode error", "error", err)
+		return nil, fmt.Errorf("parsing network: %w", err)
+	}
+	return &network, nil
+}
+
+// GetNetwork fetches a network by name, including its settings. If the network does not
+// exist or is not accessible a *NotFoundError is returned.
+func (s *NetworkService) GetNetwork(ctx context.Context, name string) (*models.Network, error) {
+	endpoint := "/self/networks/" + url.PathEscape(name) + "/"
+	ctx = client.WithEndpointTemplate(ctx, "/self/networks/{name}/")
+	respBody, err := s.Client.DoRequest(ctx, "GET", endpoint, nil)
+	if client.IsNotFound(err) {
+		return nil, &NotFoundError{Resource: "network", Key: name, Err: err}
+	}
+	if err != nil {
+		s.Client.Logger().Debug("NetworkService: API error", "error", err)
+		return nil, err
+	}
+
+	var network models.Network
+	if err := json.Unmarshal(respBody, &network); err != nil {
+		s.Client.Logger().Error("NetworkService: decode error", "error", err)
+		return nil, fmt.Errorf("parsing network: %w", err)
+	}
+	return &network, nil
+}
+
+// GetNetworkSettings fetches the settings of the named network.
+func (s *NetworkService) GetNetworkSettings(ctx context.Context, name string) (*models.NetworkSettings, error) {
+	network, err := s.GetNetwork(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if network.Settings == nil {
+		return &models.NetworkSettings{}, nil
+	}
+	return network.Settings, nil
+}
+
+// GetSubscriptionQuotas fetches the subscription allowance and usage of the named network,
+// one entry per subscription type.
+func (s *NetworkService) GetSubscriptionQuotas(ctx context.Context, name string) ([]models.NetworkSubscriptionQuota, error) {
+	endpoint := "/self/networks/" + url.PathEscape(name) + "/subscriptions/quotas/"
+	ctx = client.WithEndpointTemplate(ctx, "/self/networks/{name}/subscriptions/quotas/")
+	respBody, err := s.Client.DoRequest(ctx, "GET", endpoint, nil)
+	if client.IsNotFound(err) {
+		return nil, &NotFoundError{Resource: "network", Key: name, Err: err}
+	}
+	if err != nil {
+		s.Client.Logger().Debug("NetworkService: API error", "error", err)
+		return nil, err
+	}
+
+	var result models.NetworkSubscriptionQuotaListResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		s.Client.Logger().Error("NetworkService: decode error", "error", err)
+		return nil, fmt.Errorf("parsing subscription quotas: %w", err)
+	}
+	return result.Items, nil
+}